@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes/admission"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	"go.uber.org/zap/zapcore"
@@ -25,6 +26,12 @@ type runtimeConfiguration struct {
 	CertSecretName string
 	// EnableCertRotation sets if cert rotation should be enabled.
 	EnableCertRotation bool
+	// AnnotationValidation sets if the validating webhook for downscaler annotations should be served.
+	AnnotationValidation bool
+	// AnnotationValidationWarnOnly sets if invalid downscaler annotations should only be warned about instead of being rejected.
+	AnnotationValidationWarnOnly bool
+	// ManualUpscalePolicy sets how manual upscales of downscaled workloads are handled, either rewrite or deny.
+	ManualUpscalePolicy string
 }
 
 func getDefaultConfig() *runtimeConfiguration {
@@ -59,6 +66,24 @@ func (c *runtimeConfiguration) parseConfigFlags() {
 		"go-kube-downscaler-webhook",
 		"secret name containing the TLS certs for the webhook (default: go-kube-downscaler-webhook)",
 	)
+	flag.BoolVar(
+		&c.AnnotationValidation,
+		"annotation-validation",
+		false,
+		"serves the validating webhook for downscaler annotations on workloads and namespaces (default: false)",
+	)
+	flag.BoolVar(
+		&c.AnnotationValidationWarnOnly,
+		"annotation-validation-warn-only",
+		false,
+		"allows objects with invalid downscaler annotations with a warning instead of rejecting them (default: false)",
+	)
+	flag.StringVar(
+		&c.ManualUpscalePolicy,
+		"manual-upscale-policy",
+		admission.ManualUpscalePolicyRewrite,
+		"how manual upscales of downscaled workloads are handled, either rewrite or deny (default: rewrite)",
+	)
 }
 
 //nolint:nonamedreturns //required for function clarity
@@ -98,6 +123,11 @@ func initComponent() (runtimeConfig *runtimeConfiguration, scopeDefault, scopeCl
 		os.Exit(1)
 	}
 
+	if err = admission.CheckManualUpscalePolicy(runtimeConfig.ManualUpscalePolicy); err != nil {
+		slog.Error("invalid manual upscale policy", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Debug(
 		"finished getting startup runtimeConfig",
 		"envScope", scopeEnv,
@@ -12,7 +12,10 @@ import (
 
 	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
 	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes/admission"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/tracing"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -34,11 +37,13 @@ type serverConfig struct {
 	config               *runtimeConfiguration
 	includedResourcesSet map[string]struct{}
 	admissionMetrics     *metrics.AdmissionMetrics
+	auditLog             *audit.Log
 }
 
 const (
 	certDir                  = "/etc/webhook/tls"
 	mutatingWebhookName      = "webhook.kube-downscaler.k8s"
+	validatingWebhookName    = "annotations.kube-downscaler.k8s"
 	defaultCAName            = "KUBEDOWNSCALER"
 	defaultCAOrg             = "KUBEDOWNSCALERORG"
 	probeAddress             = ":8080"
@@ -53,7 +58,13 @@ func main() {
 	scheme := apimachineryruntime.NewScheme()
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
-	client, err := kubernetes.NewClient(config.Kubeconfig, config.DryRun, config.Qps, config.Burst)
+	auditLog, err := audit.NewLog(config.AuditLog, config.AuditLogMaxSize, config.AuditLogMaxBackups)
+	if err != nil {
+		slog.Error("failed to create audit log", "error", err)
+		os.Exit(1)
+	}
+
+	client, err := kubernetes.NewClient(config.Kubeconfig, config.DryRun, config.Qps, config.Burst, auditLog)
 	if err != nil {
 		slog.Error("failed to create new Kubernetes client", "error", err)
 		os.Exit(1)
@@ -61,12 +72,18 @@ func main() {
 
 	// Create a second client that is not in dry-run mode, for cert rotation which should always be performed
 	// even when other operations are in dry-run mode
-	clientNoDryRun, err := kubernetes.NewClient(config.Kubeconfig, false, config.Qps, config.Burst)
+	clientNoDryRun, err := kubernetes.NewClient(config.Kubeconfig, false, config.Qps, config.Burst, nil)
 	if err != nil {
 		slog.Error("failed to create new Kubernetes client", "error", err)
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := tracing.Setup(config.TracingEndpoint, config.TracingInsecure, "kube-downscaler-webhook", context.Background())
+	if err != nil {
+		slog.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
 	admissionMetrics, bindAddress := initAdmissionMetrics(config)
 
 	includedResourcesSet := toSet(config.IncludeResources)
@@ -80,6 +97,7 @@ func main() {
 		config:               config,
 		includedResourcesSet: includedResourcesSet,
 		admissionMetrics:     admissionMetrics,
+		auditLog:             auditLog,
 	}
 
 	opts := setupControllerRuntimeLogEncoding(config)
@@ -137,6 +155,11 @@ func main() {
 			Ready:               certReady,
 			Client:              clientNoDryRun,
 		}
+
+		if config.AnnotationValidation {
+			certManager.ValidatingWebhookName = validatingWebhookName
+		}
+
 		if err = certManager.AddCertificateRotation(ctx, mgr); err != nil {
 			slog.Error("failed to add certificate rotation", "error", err)
 			os.Exit(1)
@@ -158,9 +181,16 @@ func main() {
 
 	hookServer := mgr.GetWebhookServer()
 	hookServer.Register("/validate-workloads", http.HandlerFunc(serverConfig.serveValidateWorkloads))
+
+	if config.AnnotationValidation {
+		hookServer.Register("/validate-annotations", http.HandlerFunc(serverConfig.serveValidateAnnotations))
+	}
+
 	startNamespaceCleanup(ctx, serverConfig, client, cancel, config.MetricsEnabled)
 
 	<-ctx.Done()
+
+	tracing.Shutdown(shutdownTracing)
 }
 
 // serveValidateWorkloads validates an admission request.
@@ -179,15 +209,26 @@ func (s *serverConfig) serveValidateWorkloads(writer http.ResponseWriter, reques
 		&s.config.IncludeLabels,
 		&s.config.ExcludeNamespaces,
 		&s.config.ExcludeWorkloads,
+		scalable.NewProtectionRules(&s.config.CommonRuntimeConfiguration),
 		s.includedResourcesSet,
 		s.config.MetricsEnabled,
 		s.admissionMetrics,
+		s.auditLog,
+		s.config.ManualUpscalePolicy,
 	)
 	admissionHandler.HandleWorkloadMutation(ctx, writer, request)
 
 	slog.Info("validation request was correctly processed")
 }
 
+// serveValidateAnnotations validates the downscaler annotations of an admission request.
+func (s *serverConfig) serveValidateAnnotations(writer http.ResponseWriter, request *http.Request) {
+	slog.Debug("received annotation validation request from uri", "requestURI", request.RequestURI)
+
+	admissionHandler := admission.NewAnnotationValidationHandler(s.config.AnnotationValidationWarnOnly, s.config.DryRun)
+	admissionHandler.HandleAnnotationValidation(request.Context(), writer, request)
+}
+
 func startManager(ctx context.Context, mgr manager.Manager) {
 	if err := mgr.Start(ctx); err != nil {
 		slog.Error("manager exited with error", "error", err)
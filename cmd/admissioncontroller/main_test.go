@@ -39,6 +39,11 @@ func (m *MockClient) GetScaledObjects(namespace string, ctx context.Context) ([]
 	return args.Get(0).([]scalable.Workload), args.Error(1)
 }
 
+func (m *MockClient) GetGlobalMode(ctx context.Context) (values.GlobalMode, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(values.GlobalMode), args.Error(1)
+}
+
 type mockCertManager struct {
 	Ready chan struct{}
 }
@@ -206,6 +211,7 @@ func TestServeValidateWorkloads(t *testing.T) {
 			mockKubeClient := &MockClient{}
 			mockKubeClient.On("GetNamespaceScope", "default", mock.Anything).Return(values.NewScope(), nil)
 			mockKubeClient.On("GetScaledObjects", "default", mock.Anything).Return([]scalable.Workload{}, nil)
+			mockKubeClient.On("GetGlobalMode", mock.Anything).Return(values.GlobalModeResume, nil)
 
 			serverConfiguration := &serverConfig{
 				client:               mockKubeClient,
@@ -237,3 +243,35 @@ func TestServeValidateWorkloads(t *testing.T) {
 		})
 	}
 }
+
+func TestServeValidateWorkloadsGlobalMode(t *testing.T) {
+	t.Parallel()
+
+	mockKubeClient := &MockClient{}
+	mockKubeClient.On("GetGlobalMode", mock.Anything).Return(values.GlobalModePause, nil)
+
+	serverConfiguration := &serverConfig{
+		client:               mockKubeClient,
+		clientNoDryRun:       mockKubeClient,
+		scopeCli:             values.NewScope(),
+		scopeEnv:             values.NewScope(),
+		scopeDefault:         values.GetDefaultScope(),
+		config:               &runtimeConfiguration{},
+		includedResourcesSet: map[string]struct{}{},
+	}
+
+	responseRecorder := httptest.NewRecorder()
+
+	serverConfiguration.serveValidateWorkloads(responseRecorder, buildValidDeploymentRequest(t))
+
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var admissionResponse admissionv1.AdmissionReview
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &admissionResponse))
+	require.NotNil(t, admissionResponse.Response)
+	require.True(t, admissionResponse.Response.Allowed)
+	require.Nil(t, admissionResponse.Response.Patch)
+	require.Contains(t, admissionResponse.Response.Result.Message, "pause")
+
+	mockKubeClient.AssertNotCalled(t, "GetNamespaceScope", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+)
+
+// cleanupReport summarizes the changes made by a cleanup run.
+type cleanupReport struct {
+	cleaned          int
+	failed           int
+	skippedResources []string
+}
+
+// stopDownscaler deletes the webhook configurations and stops the deployments of the downscaler,
+// so they don't downscale the workloads again while they are cleaned up.
+func stopDownscaler(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) error {
+	for _, webhook := range config.CleanupDeleteWebhooks {
+		slog.Info("deleting webhook configuration before cleaning up", "name", webhook)
+
+		err := client.DeleteWebhookConfiguration(webhook, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook configuration: %w", err)
+		}
+	}
+
+	for _, deployment := range config.CleanupStopDeployments {
+		slog.Info("stopping deployment before cleaning up", "deployment", deployment)
+
+		err := client.StopDeployment(deployment, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to stop deployment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// runCleanup upscales all workloads of the included resource types and removes all state managed by the downscaler.
+// Resource types which can't be listed (e.g. because they aren't installed) are skipped.
+func runCleanup(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) *cleanupReport {
+	report := &cleanupReport{}
+
+	for _, resource := range config.IncludeResources {
+		workloads, err := client.GetWorkloads(config.IncludeNamespaces, []string{resource}, ctx)
+		if err != nil {
+			slog.Info("skipping resource type, it isn't installed or can't be listed", "resourceType", resource, "error", err)
+			report.skippedResources = append(report.skippedResources, resource)
+
+			continue
+		}
+
+		for _, workload := range workloads {
+			cleanupWorkload(client, ctx, workload, config, report)
+		}
+	}
+
+	return report
+}
+
+// cleanupWorkload cleans up a single workload and its routes and records the result in the report.
+func cleanupWorkload(
+	client kubernetes.Client,
+	ctx context.Context,
+	workload scalable.Workload,
+	config *runtimeConfiguration,
+	report *cleanupReport,
+) {
+	changed, err := client.CleanupWorkload(workload, ctx)
+	if err != nil {
+		slog.Error("failed to clean up workload", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+		report.failed++
+
+		return
+	}
+
+	if config.MaintenanceService != "" {
+		err = client.RestoreRoutes(workload, ctx)
+		if err != nil {
+			slog.Error("failed to restore routes", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+			report.failed++
+
+			return
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	slog.Info(
+		"cleaned up workload",
+		"workload", workload.GetName(),
+		"namespace", workload.GetNamespace(),
+		"kind", workload.GroupVersionKind().Kind,
+	)
+
+	report.cleaned++
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCleanup(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	config.IncludeResources = []string{"deployments", "nodepools"}
+
+	downscaled := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "downscaled", "namespace": "default", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 0}
+	}`)
+	upscaled := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "upscaled", "namespace": "default"},
+		"spec": {"replicas": 2}
+	}`)
+	broken := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "broken", "namespace": "default", "annotations": {"downscaler/original-replicas": "1"}},
+		"spec": {"replicas": 0}
+	}`)
+
+	client := new(MockClient)
+	client.On("GetWorkloads", config.IncludeNamespaces, []string{"deployments"}, ctx).
+		Return([]scalable.Workload{downscaled, upscaled, broken}, nil)
+	client.On("GetWorkloads", config.IncludeNamespaces, []string{"nodepools"}, ctx).
+		Return([]scalable.Workload{}, errors.New("the server could not find the requested resource"))
+	client.On("CleanupWorkload", downscaled, ctx).Return(true, nil)
+	client.On("CleanupWorkload", upscaled, ctx).Return(false, nil)
+	client.On("CleanupWorkload", broken, ctx).Return(false, errors.New("conflict"))
+
+	report := runCleanup(client, ctx, config)
+
+	assert.Equal(t, 1, report.cleaned)
+	assert.Equal(t, 1, report.failed)
+	assert.Equal(t, []string{"nodepools"}, report.skippedResources)
+	client.AssertNumberOfCalls(t, "CleanupWorkload", 3)
+	client.AssertNumberOfCalls(t, "GetWorkloads", 2)
+}
+
+func TestStopDownscaler(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	config.CleanupDeleteWebhooks = []string{"webhook.kube-downscaler.k8s"}
+	config.CleanupStopDeployments = []string{"downscaler", "downscaler-webhook"}
+
+	t.Run("webhooks are deleted and deployments are stopped", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockClient)
+		client.On("DeleteWebhookConfiguration", "webhook.kube-downscaler.k8s", ctx).Return(nil)
+		client.On("StopDeployment", "downscaler", ctx).Return(nil)
+		client.On("StopDeployment", "downscaler-webhook", ctx).Return(nil)
+
+		require.NoError(t, stopDownscaler(client, ctx, config))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failing to stop a deployment fails", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockClient)
+		client.On("DeleteWebhookConfiguration", "webhook.kube-downscaler.k8s", ctx).Return(nil)
+		client.On("StopDeployment", "downscaler", ctx).Return(errors.New("forbidden"))
+
+		require.Error(t, stopDownscaler(client, ctx, config))
+		client.AssertNotCalled(t, "StopDeployment", "downscaler-webhook", ctx)
+	})
+}
+
+func TestRunCommandReturnsExitCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	config.Cleanup = true
+	config.CleanupStopDeployments = []string{"downscaler"}
+
+	client := new(MockClient)
+	client.On("StopDeployment", "downscaler", ctx).Return(errors.New("forbidden"))
+
+	assert.Equal(t, 1, runCommand(client, ctx, config), "failing commands should return a non-zero exit code")
+	client.AssertExpectations(t)
+}
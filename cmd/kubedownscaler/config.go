@@ -3,9 +3,11 @@ package main
 import (
 	"flag"
 	"log/slog"
+	"math"
 	"os"
 	"time"
 
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 )
@@ -21,13 +23,76 @@ type runtimeConfiguration struct {
 	Interval time.Duration
 	// MaxRetriesOnConflict sets the maximum number of retries on 409 errors.
 	MaxRetriesOnConflict int
+	// MaxConcurrentScans sets how many workloads are scanned in parallel.
+	MaxConcurrentScans int
+	// ScanTimeout sets the deadline of a single scan cycle. Scan cycles don't have a deadline if not set.
+	ScanTimeout time.Duration
+	// ShutdownGracePeriod sets how long in-flight scalings may continue after a shutdown signal.
+	ShutdownGracePeriod time.Duration
+	// MaxChangesPerCycle sets how many workloads may be downscaled in a single cycle before the cycle is aborted.
+	MaxChangesPerCycle values.ChangeLimit
+	// MaxChangesPerNamespace sets how many workloads of a namespace may be downscaled in a single cycle before the cycle is aborted.
+	MaxChangesPerNamespace values.ChangeLimit
+	// DriftPolicy sets how manual changes made to workloads while they are downscaled are handled.
+	DriftPolicy values.DriftPolicy
+	// Cleanup sets if all workloads should be upscaled and all state managed by the downscaler removed instead of scanning.
+	Cleanup bool
+	// CleanupStopDeployments sets the deployments ("[namespace/]name") which are scaled to zero before cleaning up,
+	// so the downscaler doesn't downscale the workloads again while they are cleaned up.
+	CleanupStopDeployments []string
+	// CleanupDeleteWebhooks sets the webhook configurations which are deleted before cleaning up.
+	CleanupDeleteWebhooks []string
+	// Snapshot sets if a snapshot of all downscaled workloads should be exported to the snapshot location instead of scanning.
+	Snapshot bool
+	// Restore sets if all workloads of the snapshot at the snapshot location should be upscaled instead of scanning.
+	Restore bool
+	// SnapshotLocation sets the file or configmap ("configmap:[namespace/]name") snapshots are written to and read from.
+	SnapshotLocation string
+	// SnapshotInterval sets how often the daemon exports snapshots. Disabled if zero.
+	SnapshotInterval time.Duration
+	// MaintenanceService sets the service routes are redirected to while their workloads are downscaled.
+	MaintenanceService string
+	// MaintenanceServicePort sets the port of the maintenance service.
+	MaintenanceServicePort int
+	// HealthMaxCycleAge sets how long ago the last successful cycle may be before the liveness probe fails.
+	HealthMaxCycleAge time.Duration
+	// HealthMaxConsecutiveFailures sets after how many consecutive failed cycles the readiness probe fails. Disabled if zero.
+	HealthMaxConsecutiveFailures int
+	// HealthRequireLeader sets if the readiness probe fails while the instance isn't the leader.
+	HealthRequireLeader bool
+	// WorkloadMetrics sets if metrics grouped by the namespace and kind of the workloads should be exposed.
+	WorkloadMetrics bool
+	// WorkloadMetricsNamespaces sets the namespaces whose workload metrics are additionally labeled with the workload name.
+	WorkloadMetricsNamespaces []string
+	// NotificationURLs sets the urls the changes of all namespaces are sent to.
+	NotificationURLs []string
+	// NotificationFormat sets the format of the notifications, either generic or cloudevents.
+	NotificationFormat string
+	// NotificationNamespaceRouting sets if the changes of a namespace are also sent to the urls in its notification annotation.
+	NotificationNamespaceRouting bool
+	// NotificationAllowedURLPrefixes sets the url prefixes the urls in the notification annotation of a namespace have to start with.
+	NotificationAllowedURLPrefixes []string
+	// WebUI sets if the web ui for viewing and temporarily overriding the scaling of namespaces should be served.
+	WebUI bool
+	// WebUIPort sets the port the web ui is served on.
+	WebUIPort int
+	// WebUIMaxDuration sets how long a namespace may be woken up or excluded at most through the web ui.
+	WebUIMaxDuration time.Duration
 }
 
 func getDefaultConfig() *runtimeConfiguration {
 	return &runtimeConfiguration{
-		CommonRuntimeConfiguration: *util.GetDefaultConfig(),
-		Once:                       false,
-		Interval:                   30 * time.Second,
+		CommonRuntimeConfiguration:   *util.GetDefaultConfig(),
+		Once:                         false,
+		Interval:                     30 * time.Second,
+		MaxConcurrentScans:           10,
+		ShutdownGracePeriod:          20 * time.Second,
+		DriftPolicy:                  values.DriftPolicyRedownscale,
+		MaintenanceServicePort:       80,
+		HealthMaxConsecutiveFailures: 3,
+		NotificationFormat:           notification.FormatGeneric,
+		WebUIPort:                    8082,
+		WebUIMaxDuration:             12 * time.Hour,
 	}
 }
 
@@ -57,6 +122,155 @@ func (c *runtimeConfiguration) parseConfigFlags() {
 		0,
 		"maximum number of retries on 409 conflict errors (default: 0)",
 	)
+	flag.IntVar(
+		&c.MaxConcurrentScans,
+		"max-concurrent-scans",
+		10, //nolint:mnd // default amount of workers
+		"maximum number of workloads scanned in parallel (default: 10)",
+	)
+	flag.Var(
+		(*util.DurationValue)(&c.ScanTimeout),
+		"scan-timeout",
+		"deadline for a single scan cycle, outstanding api calls are cancelled once it is reached (default: none)",
+	)
+	flag.Var(
+		(*util.DurationValue)(&c.ShutdownGracePeriod),
+		"shutdown-grace-period",
+		"time in-flight scalings may take to finish after a shutdown signal was received (default: 20s)",
+	)
+	flag.Var(
+		&c.MaxChangesPerCycle,
+		"max-changes-per-cycle",
+		"maximum amount or percentage of workloads downscaled per cycle, the cycle is aborted if exceeded (default: unlimited)",
+	)
+	flag.Var(
+		&c.MaxChangesPerNamespace,
+		"max-changes-per-namespace",
+		"maximum amount or percentage of workloads of a namespace downscaled per cycle, the cycle is aborted if exceeded (default: unlimited)",
+	)
+	flag.Var(
+		&c.DriftPolicy,
+		"drift-policy",
+		"how manual changes to downscaled workloads are handled, either redownscale, adopt or ignore (default: redownscale)",
+	)
+	flag.BoolVar(
+		&c.Cleanup,
+		"cleanup",
+		false,
+		"upscale all workloads, remove all annotations and selectors managed by the downscaler and exit (default: false)",
+	)
+	flag.Var(
+		(*util.StringListValue)(&c.CleanupStopDeployments),
+		"cleanup-stop-deployments",
+		"deployments in the format [namespace/]name which are scaled to zero before cleaning up (optional)",
+	)
+	flag.Var(
+		(*util.StringListValue)(&c.CleanupDeleteWebhooks),
+		"cleanup-delete-webhooks",
+		"mutating and validating webhook configurations which are deleted before cleaning up (optional)",
+	)
+	flag.BoolVar(
+		&c.Snapshot,
+		"snapshot",
+		false,
+		"export a snapshot of the original state of all downscaled workloads to the snapshot location and exit (default: false)",
+	)
+	flag.BoolVar(
+		&c.Restore,
+		"restore",
+		false,
+		"upscale all workloads from the snapshot at the snapshot location and exit (default: false)",
+	)
+	flag.StringVar(
+		&c.SnapshotLocation,
+		"snapshot-location",
+		"",
+		"file or configmap in the format configmap:[namespace/]name snapshots are written to and read from (optional)",
+	)
+	flag.Var(
+		(*util.DurationValue)(&c.SnapshotInterval),
+		"snapshot-interval",
+		"time between snapshots exported by the daemon, requires a snapshot location (default: disabled)",
+	)
+	flag.StringVar(
+		&c.MaintenanceService,
+		"maintenance-service",
+		"",
+		"service in the format [namespace/]name routes are redirected to while their workloads are downscaled (optional)",
+	)
+	flag.IntVar(
+		&c.MaintenanceServicePort,
+		"maintenance-service-port",
+		80, //nolint:mnd // default http port
+		"port of the maintenance service (default: 80)",
+	)
+	flag.Var(
+		(*util.DurationValue)(&c.HealthMaxCycleAge),
+		"health-max-cycle-age",
+		"maximum time since the last successful cycle before the liveness probe fails (default: 3 times the interval plus the scan timeout)",
+	)
+	flag.IntVar(
+		&c.HealthMaxConsecutiveFailures,
+		"health-max-consecutive-failures",
+		3, //nolint:mnd // default amount of failed cycles
+		"amount of consecutive failed cycles after which the readiness probe fails, 0 disables the check (default: 3)",
+	)
+	flag.BoolVar(
+		&c.HealthRequireLeader,
+		"health-require-leader",
+		false,
+		"fail the readiness probe while the instance isn't the leader (default: false)",
+	)
+	flag.BoolVar(
+		&c.WorkloadMetrics,
+		"workload-metrics",
+		false,
+		"expose metrics broken down by the namespace and kind of the workloads, requires metrics (default: false)",
+	)
+	flag.Var(
+		(*util.StringListValue)(&c.WorkloadMetricsNamespaces),
+		"workload-metrics-namespaces",
+		"namespaces whose workload metrics are additionally labeled with the workload name (default: none)",
+	)
+	flag.Var(
+		(*util.StringListValue)(&c.NotificationURLs),
+		"notification-urls",
+		"urls the changes of all namespaces are posted to after each scan cycle (default: none)",
+	)
+	flag.StringVar(
+		&c.NotificationFormat,
+		"notification-format",
+		notification.FormatGeneric,
+		"format of the notifications, either generic or cloudevents (default: generic)",
+	)
+	flag.BoolVar(
+		&c.NotificationNamespaceRouting,
+		"notification-namespace-routing",
+		false,
+		"also post the changes of a namespace to the urls in its downscaler/notification-urls annotation (default: false)",
+	)
+	flag.Var(
+		(*util.StringListValue)(&c.NotificationAllowedURLPrefixes),
+		"notification-allowed-url-prefixes",
+		"url prefixes the urls in the notification annotation of a namespace have to start with, required for namespace routing (default: none)",
+	)
+	flag.BoolVar(
+		&c.WebUI,
+		"web-ui",
+		false,
+		"serve the web ui for viewing and temporarily overriding the scaling of namespaces (default: false)",
+	)
+	flag.IntVar(
+		&c.WebUIPort,
+		"web-ui-port",
+		8082, //nolint:mnd // default port of the web ui
+		"port the web ui is served on (default: 8082)",
+	)
+	flag.Var(
+		(*util.DurationValue)(&c.WebUIMaxDuration),
+		"web-ui-max-duration",
+		"how long a namespace may be woken up or excluded at most through the web ui (default: 12h)",
+	)
 }
 
 //nolint:nonamedreturns //required for function clarity
@@ -70,6 +284,15 @@ func initComponent() (config *runtimeConfiguration, scopeDefault, scopeCli, scop
 		os.Exit(1)
 	}
 
+	// the notification urls may contain tokens, so they can also be set from a secret
+	if len(config.NotificationURLs) == 0 {
+		err = util.GetEnvValue("NOTIFICATION_URLS", (*util.StringListValue)(&config.NotificationURLs))
+		if err != nil {
+			slog.Error("failed to parse env vars for config", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	scopeDefault, scopeCli, scopeEnv = values.InitScopes()
 
 	if config.JsonLogs {
@@ -91,6 +314,35 @@ func initComponent() (config *runtimeConfiguration, scopeDefault, scopeCli, scop
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	if config.MaxConcurrentScans < 1 {
+		slog.Error("max concurrent scans has to be at least 1", "maxConcurrentScans", config.MaxConcurrentScans)
+		os.Exit(1)
+	}
+
+	if config.HealthMaxCycleAge <= 0 {
+		config.HealthMaxCycleAge = 3*config.Interval + config.ScanTimeout //nolint:mnd // a few missed cycles are tolerated
+	}
+
+	if (config.Snapshot || config.Restore || config.SnapshotInterval > 0) && config.SnapshotLocation == "" {
+		slog.Error("a snapshot location is required to export or restore snapshots")
+		os.Exit(1)
+	}
+
+	if countEnabled(config.Snapshot, config.Restore, config.Cleanup) > 1 {
+		slog.Error("only one of snapshot, restore and cleanup can be used at the same time")
+		os.Exit(1)
+	}
+
+	if config.WorkloadMetrics && !config.MetricsEnabled {
+		slog.Error("workload metrics require metrics to be enabled")
+		os.Exit(1)
+	}
+
+	if config.MaintenanceServicePort < 1 || config.MaintenanceServicePort > math.MaxUint16 {
+		slog.Error("invalid maintenance service port", "port", config.MaintenanceServicePort)
+		os.Exit(1)
+	}
+
 	if err = scopeCli.CheckForIncompatibleFields(); err != nil {
 		slog.Error("found incompatible fields", "error", err)
 		os.Exit(1)
@@ -105,3 +357,16 @@ func initComponent() (config *runtimeConfiguration, scopeDefault, scopeCli, scop
 
 	return config, scopeDefault, scopeCli, scopeEnv
 }
+
+// countEnabled counts how many of the given options are enabled.
+func countEnabled(options ...bool) int {
+	enabled := 0
+
+	for _, option := range options {
+		if option {
+			enabled++
+		}
+	}
+
+	return enabled
+}
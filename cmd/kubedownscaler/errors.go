@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type NamespaceScopeRetrieveError struct {
 	namespace string
@@ -26,6 +29,36 @@ func (m *MaxRetriesExceededError) Error() string {
 	return fmt.Sprintf("failed to scale resource: number of max retries exceeded (%d) will try again in the next cycle", m.maxRetries)
 }
 
+type ScanInterruptedError struct {
+	skipped int
+}
+
+func newScanInterruptedError(skipped int) error {
+	return &ScanInterruptedError{skipped: skipped}
+}
+
+func (s *ScanInterruptedError) Error() string {
+	return fmt.Sprintf("scan cycle was interrupted, %d workloads were skipped until the next cycle", s.skipped)
+}
+
+type SafetyLimitExceededError struct {
+	scope   string
+	changes int
+	total   int
+	limit   string
+}
+
+func newSafetyLimitExceededError(scope string, changes, total int, limit string) error {
+	return &SafetyLimitExceededError{scope: scope, changes: changes, total: total, limit: limit}
+}
+
+func (s *SafetyLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"safety limit of the %s exceeded: %d of %d workloads would be downscaled, but the limit is %s",
+		s.scope, s.changes, s.total, s.limit,
+	)
+}
+
 type ScalingInvalidError struct {
 	message string
 }
@@ -57,3 +90,49 @@ type MetricsDisabledError struct{}
 func (e *MetricsDisabledError) Error() string {
 	return "metrics are disabled"
 }
+
+var ErrLeadershipLost = &LeadershipLostError{}
+
+type LeadershipLostError struct{}
+
+func (e *LeadershipLostError) Error() string {
+	return "lost the leader lease"
+}
+
+type SnapshotRestoreError struct {
+	failed int
+	total  int
+}
+
+func newSnapshotRestoreError(failed, total int) error {
+	return &SnapshotRestoreError{failed: failed, total: total}
+}
+
+func (s *SnapshotRestoreError) Error() string {
+	return fmt.Sprintf("failed to restore %d of %d workloads of the snapshot", s.failed, s.total)
+}
+
+type CleanupFailedError struct {
+	failed int
+}
+
+func newCleanupFailedError(failed int) error {
+	return &CleanupFailedError{failed: failed}
+}
+
+func (c *CleanupFailedError) Error() string {
+	return fmt.Sprintf("failed to clean up %d workloads", c.failed)
+}
+
+type InvalidOverrideDurationError struct {
+	duration    string
+	maxDuration time.Duration
+}
+
+func newInvalidOverrideDurationError(duration string, maxDuration time.Duration) error {
+	return &InvalidOverrideDurationError{duration: duration, maxDuration: maxDuration}
+}
+
+func (i *InvalidOverrideDurationError) Error() string {
+	return fmt.Sprintf("the duration has to be positive and at most %s, got %q", i.maxDuration, i.duration)
+}
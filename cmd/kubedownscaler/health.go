@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+)
+
+// connectionCheckTimeout is how long the readiness check waits for the api server, it has to be shorter than the probe timeout.
+const connectionCheckTimeout = time.Second
+
+// healthCheck is the result of a single check of a health probe.
+type healthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// healthResponse is the response of a health probe.
+type healthResponse struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []healthCheck `json:"checks"`
+}
+
+// checkLiveness checks if the downscaler is stuck. While scanning, a cycle has to succeed within the max cycle age.
+// The age is counted from when the instance started scanning, so instances which just started or took over the lease aren't stuck.
+func checkLiveness(health scanHealth, config *runtimeConfiguration, now time.Time) []healthCheck {
+	check := healthCheck{Name: "lastSuccessfulCycle", Healthy: true}
+
+	if !health.leading {
+		check.Message = "not scanning, waiting for the leader lease"
+		return []healthCheck{check}
+	}
+
+	since := health.leadingSince
+	if health.lastSuccessfulCycle.After(since) {
+		since = health.lastSuccessfulCycle
+	}
+
+	age := now.Sub(since).Round(time.Second)
+	check.Healthy = age <= config.HealthMaxCycleAge
+	check.Message = fmt.Sprintf("last successful cycle %s ago, the maximum is %s", age, config.HealthMaxCycleAge)
+
+	return []healthCheck{check}
+}
+
+// checkReadiness checks if the api server can be reached, the last cycles didn't fail and if required if this instance is the leader.
+func checkReadiness(health scanHealth, client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) []healthCheck {
+	checks := make([]healthCheck, 0, 3) //nolint:mnd // amount of readiness checks
+
+	connectionCheck := healthCheck{Name: "apiServer", Healthy: true}
+
+	err := client.CheckConnection(ctx)
+	if err != nil {
+		connectionCheck.Healthy = false
+		connectionCheck.Message = err.Error()
+	}
+
+	checks = append(checks, connectionCheck)
+
+	failuresCheck := healthCheck{
+		Name:    "consecutiveFailures",
+		Healthy: config.HealthMaxConsecutiveFailures <= 0 || health.consecutiveFailures < config.HealthMaxConsecutiveFailures,
+		Message: fmt.Sprintf("%d consecutive failed cycles, the maximum is %d", health.consecutiveFailures, config.HealthMaxConsecutiveFailures),
+	}
+	if health.lastFailure != "" {
+		failuresCheck.Message += ", last failure: " + health.lastFailure
+	}
+
+	checks = append(checks, failuresCheck)
+
+	leaderCheck := healthCheck{Name: "leader", Healthy: health.leading || !config.HealthRequireLeader, Message: "leading"}
+	if !health.leading {
+		leaderCheck.Message = "not leading"
+	}
+
+	checks = append(checks, leaderCheck)
+
+	return checks
+}
+
+// writeHealthResponse writes the checks as json, with status 503 if any of them is unhealthy.
+func writeHealthResponse(w http.ResponseWriter, checks []healthCheck) {
+	response := healthResponse{Healthy: true, Checks: checks}
+
+	for _, check := range checks {
+		if !check.Healthy {
+			response.Healthy = false
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !response.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.Error("failed to write health response", "error", err)
+	}
+}
+
+// handleLiveness serves the liveness probe.
+func handleLiveness(status *scanStatus, config *runtimeConfiguration) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeHealthResponse(w, checkLiveness(status.getHealth(), config, time.Now()))
+	}
+}
+
+// handleReadiness serves the readiness probe.
+func handleReadiness(status *scanStatus, client kubernetes.Client, config *runtimeConfiguration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), connectionCheckTimeout)
+		defer cancel()
+
+		writeHealthResponse(w, checkReadiness(status.getHealth(), client, ctx, config))
+	}
+}
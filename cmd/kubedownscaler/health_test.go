@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckLiveness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	config := getDefaultConfig()
+	config.HealthMaxCycleAge = 2 * time.Minute
+
+	tests := []struct {
+		name        string
+		health      scanHealth
+		wantHealthy bool
+	}{
+		{
+			name:        "not leading",
+			health:      scanHealth{leading: false},
+			wantHealthy: true,
+		},
+		{
+			name:        "just started leading",
+			health:      scanHealth{leading: true, leadingSince: now.Add(-time.Minute)},
+			wantHealthy: true,
+		},
+		{
+			name:        "recent successful cycle",
+			health:      scanHealth{leading: true, leadingSince: now.Add(-time.Hour), lastSuccessfulCycle: now.Add(-time.Minute)},
+			wantHealthy: true,
+		},
+		{
+			name:        "stuck",
+			health:      scanHealth{leading: true, leadingSince: now.Add(-time.Hour), lastSuccessfulCycle: now.Add(-10 * time.Minute)},
+			wantHealthy: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			checks := checkLiveness(test.health, config, now)
+			assert.Len(t, checks, 1)
+			assert.Equal(t, test.wantHealthy, checks[0].Healthy)
+		})
+	}
+}
+
+func TestCheckReadiness(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		health        scanHealth
+		connectionErr error
+		requireLeader bool
+		wantUnhealthy []string
+	}{
+		{
+			name:   "healthy",
+			health: scanHealth{leading: true, consecutiveFailures: 2},
+		},
+		{
+			name:          "api server unreachable",
+			health:        scanHealth{leading: true},
+			connectionErr: errors.New("connection refused"),
+			wantUnhealthy: []string{"apiServer"},
+		},
+		{
+			name:          "too many failed cycles",
+			health:        scanHealth{leading: true, consecutiveFailures: 3, lastFailure: "deadline exceeded"},
+			wantUnhealthy: []string{"consecutiveFailures"},
+		},
+		{
+			name:   "not leading",
+			health: scanHealth{leading: false},
+		},
+		{
+			name:          "not leading but leader required",
+			health:        scanHealth{leading: false},
+			requireLeader: true,
+			wantUnhealthy: []string{"leader"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			config := getDefaultConfig()
+			config.HealthRequireLeader = test.requireLeader
+
+			client := new(MockClient)
+			client.On("CheckConnection", mock.Anything).Return(test.connectionErr)
+
+			var unhealthy []string
+
+			for _, check := range checkReadiness(test.health, client, ctx, config) {
+				if !check.Healthy {
+					unhealthy = append(unhealthy, check.Name)
+				}
+			}
+
+			assert.Equal(t, test.wantUnhealthy, unhealthy)
+		})
+	}
+}
+
+func TestRecordCycleHealth(t *testing.T) {
+	t.Parallel()
+
+	status := newScanStatus()
+
+	status.startCycle("", "")
+	status.finishCycle(errors.New("deadline exceeded"))
+	assert.Equal(t, 1, status.getHealth().consecutiveFailures)
+
+	status.startCycle("", "")
+	status.recordError(newDeploymentFromJSON(t, `{"metadata": {"name": "a", "namespace": "default"}}`), errors.New("forbidden"))
+	status.finishCycle(nil)
+	assert.Equal(t, 2, status.getHealth().consecutiveFailures)
+
+	status.startCycle("", "")
+	status.finishCycle(newSafetyLimitExceededError("cycle", 5, 10, "2"))
+	assert.Equal(t, 0, status.getHealth().consecutiveFailures)
+	assert.False(t, status.getHealth().lastSuccessfulCycle.IsZero())
+}
+
+func TestWriteHealthResponse(t *testing.T) {
+	t.Parallel()
+
+	recorder := httptest.NewRecorder()
+	writeHealthResponse(recorder, []healthCheck{{Name: "apiServer", Healthy: true}, {Name: "leader", Healthy: false}})
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t,
+		`{"healthy":false,"checks":[{"name":"apiServer","healthy":true},{"name":"leader","healthy":false}]}`,
+		recorder.Body.String(),
+	)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -15,9 +16,13 @@ import (
 	_ "time/tzdata"
 
 	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/tracing"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/server/mux"
 	"k8s.io/client-go/tools/leaderelection"
@@ -31,28 +36,122 @@ const (
 func main() {
 	config, scopeDefault, scopeCli, scopeEnv := initComponent()
 
+	auditLog, err := audit.NewLog(config.AuditLog, config.AuditLogMaxSize, config.AuditLogMaxBackups)
+	if err != nil {
+		slog.Error("failed to create audit log", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Debug("getting client for kubernetes")
 
-	client, err := kubernetes.NewClient(config.Kubeconfig, config.DryRun, config.Qps, config.Burst)
+	client, err := kubernetes.NewClient(config.Kubeconfig, config.DryRun, config.Qps, config.Burst, auditLog)
 	if err != nil {
 		slog.Error("failed to create new Kubernetes client", "error", err)
 		os.Exit(1)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	notifier, err := notification.NewNotifier(
+		config.NotificationURLs,
+		config.NotificationFormat,
+		config.NotificationNamespaceRouting,
+		config.NotificationAllowedURLPrefixes,
+		client.GetNamespaceAnnotations,
+	)
+	if err != nil {
+		slog.Error("failed to create notifier", "error", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(config.TracingEndpoint, config.TracingInsecure, "kube-downscaler", context.Background())
+	if err != nil {
+		slog.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	exitCode := 0
+
+	// os.Exit skips the deferred calls, so it is deferred first to only exit once all others have run
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
 	defer cancel()
+	defer tracing.Shutdown(shutdownTracing)
+
+	if config.Snapshot || config.Restore || config.Cleanup {
+		exitCode = runCommand(client, ctx, config)
+		return
+	}
+
+	status := newScanStatus()
 
-	go serveHealth()
+	go serveHealth(status, client, config)
+
+	if config.WebUI {
+		go serveWebUI(status, client, config)
+	}
 
 	downscalerMetrics := initMetrics(config)
 
+	go notifier.Run()
+	defer notifier.Close()
+
 	if !config.LeaderElection {
-		runWithoutLeaderElection(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics)
+		runWithoutLeaderElection(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics, status, notifier)
 		return
 	}
 
-	runWithLeaderElection(client, cancel, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics)
+	runWithLeaderElection(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics, status, notifier)
+}
+
+// runCommand runs a cleanup or exports or restores a snapshot instead of running the downscaler.
+// It returns the exit code of the command.
+func runCommand(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) int {
+	if config.Cleanup {
+		err := stopDownscaler(client, ctx, config)
+		if err != nil {
+			slog.Error("failed to stop the downscaler, workloads could be downscaled again while they are cleaned up", "error", err)
+			return 1
+		}
+
+		report := runCleanup(client, ctx, config)
+
+		slog.Info(
+			"finished cleanup",
+			"cleanedWorkloads", report.cleaned,
+			"failedWorkloads", report.failed,
+			"skippedResourceTypes", report.skippedResources,
+		)
+
+		if report.failed > 0 {
+			slog.Error("cleanup didn't finish successfully", "error", newCleanupFailedError(report.failed))
+			return 1
+		}
+
+		return 0
+	}
+
+	if config.Snapshot {
+		err := exportSnapshot(client, ctx, config)
+		if err != nil {
+			slog.Error("failed to export snapshot", "error", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	err := importSnapshot(client, ctx, config)
+	if err != nil {
+		slog.Error("failed to restore snapshot", "error", err)
+		return 1
+	}
+
+	return 0
 }
 
 // serveMetrics starts the metrics server for the downscaler.
@@ -81,17 +180,14 @@ func serveMetrics() {
 	slog.Info("serving metrics on /metrics")
 }
 
-// serveHealth starts the health server for the downscaler.
-func serveHealth() {
+// serveHealth starts the health server for the downscaler, which also serves the read-only status api.
+func serveHealth(status *scanStatus, client kubernetes.Client, config *runtimeConfiguration) {
 	pathRecorderMux := http.NewServeMux()
 
-	pathRecorderMux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	pathRecorderMux.Handle("/healthz", handleLiveness(status, config))
+	pathRecorderMux.Handle("/readyz", handleReadiness(status, client, config))
 
-	pathRecorderMux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	pathRecorderMux.Handle("/api/v1/status", status)
 
 	server := &http.Server{
 		Addr:         ":8081",
@@ -108,14 +204,35 @@ func serveHealth() {
 	}
 }
 
+// serveWebUI starts the server of the web ui.
+func serveWebUI(status *scanStatus, client kubernetes.Client, config *runtimeConfiguration) {
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", config.WebUIPort),
+		Handler:      newWebUIHandler(status, client, config.WebUIMaxDuration),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	slog.Info("serving web ui", "port", config.WebUIPort)
+
+	err := server.ListenAndServe()
+	if err != nil {
+		slog.Error("failed to start web ui server", "error", err)
+		os.Exit(1)
+	}
+}
+
 // runWithLeaderElection runs the downscaler with leader election enabled.
+// Once leading, the lease is only released after scanning stopped, so in-flight scalings can finish before another replica takes over.
 func runWithLeaderElection(
 	client kubernetes.Client,
-	cancel context.CancelFunc,
 	ctx context.Context,
 	scopeDefault, scopeCli, scopeEnv *values.Scope,
 	config *runtimeConfiguration,
 	downscalerMetrics *metrics.Metrics,
+	status *scanStatus,
+	notifier *notification.Notifier,
 ) {
 	lease, err := client.CreateLease(leaseName)
 	if err != nil {
@@ -123,33 +240,44 @@ func runWithLeaderElection(
 		os.Exit(1)
 	}
 
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	leaderCtx, cancelLeader := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelLeader()
 
-	go func() {
-		<-sigs
-		cancel()
-	}()
+	stopCancelLeaderOnShutdown := context.AfterFunc(ctx, cancelLeader)
 
-	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+	leaderelection.RunOrDie(leaderCtx, leaderelection.LeaderElectionConfig{
 		Lock:            lease,
 		ReleaseOnCancel: true,
 		LeaseDuration:   30 * time.Second,
 		RenewDeadline:   20 * time.Second,
 		RetryPeriod:     5 * time.Second,
 		Callbacks: leaderelection.LeaderCallbacks{
-			OnStartedLeading: func(ctx context.Context) {
+			OnStartedLeading: func(leadingCtx context.Context) {
 				slog.Info("started leading")
+				status.setLeading(true)
+
+				defer cancelLeader()
+				defer status.setLeading(false)
+
+				stopCancelLeaderOnShutdown()
+
+				// the cause tells in-flight scalings to stop immediately when the leadership is lost, as a new leader may already scale
+				scanCtx, cancelScan := context.WithCancelCause(context.WithoutCancel(leadingCtx))
+				defer cancelScan(nil)
 
-				err = startScanning(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics)
+				stopCancelScanOnLostLeadership := context.AfterFunc(leadingCtx, func() { cancelScan(ErrLeadershipLost) })
+				defer stopCancelScanOnLostLeadership()
+
+				stopCancelScanOnShutdown := context.AfterFunc(ctx, func() { cancelScan(nil) })
+				defer stopCancelScanOnShutdown()
+
+				err = startScanning(client, scanCtx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics, status, notifier)
 				if err != nil {
 					slog.Error("an error occurred while scanning workloads", "error", err)
-					cancel()
 				}
 			},
 			OnStoppedLeading: func() {
 				slog.Info("stopped leading")
-				cancel()
 			},
 			OnNewLeader: func(identity string) {
 				slog.Info("new leader elected", "identity", identity)
@@ -165,10 +293,13 @@ func runWithoutLeaderElection(
 	scopeDefault, scopeCli, scopeEnv *values.Scope,
 	config *runtimeConfiguration,
 	downscalerMetrics *metrics.Metrics,
+	status *scanStatus,
+	notifier *notification.Notifier,
 ) {
 	slog.Warn("proceeding without leader election; this could cause errors when running with multiple replicas")
+	status.setLeading(true)
 
-	err := startScanning(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics)
+	err := startScanning(client, ctx, scopeDefault, scopeCli, scopeEnv, config, downscalerMetrics, status, notifier)
 	if err != nil {
 		slog.Error("an error occurred while scanning workloads, exiting", "error", err)
 		os.Exit(1)
@@ -182,97 +313,515 @@ func startScanning(
 	scopeDefault, scopeCli, scopeEnv *values.Scope,
 	config *runtimeConfiguration,
 	downscalerMetrics *metrics.Metrics,
+	status *scanStatus,
+	notifier *notification.Notifier,
 ) error {
 	slog.Info("started downscaler")
 
 	previousNamespacesToMetrics := newNamespaceToMetrics(config)
+	globalMode := values.GlobalModeResume
+
+	var lastSnapshot time.Time
+
+	downscalerMetrics.UpdateGlobalMode(config.MetricsEnabled, string(globalMode))
 
 	for {
-		slog.Info("scanning workloads")
+		globalMode = refreshGlobalMode(client, ctx, globalMode, downscalerMetrics, config)
+
+		if globalMode == values.GlobalModePause {
+			slog.Info("downscaler is paused by the global mode, skipping scan")
+			status.recordPausedCycle()
+		} else {
+			currentNamespaceToMetrics, err := runMeasuredScanCycle(
+				client,
+				ctx,
+				scopeDefault, scopeCli, scopeEnv,
+				globalMode,
+				previousNamespacesToMetrics,
+				config,
+				downscalerMetrics,
+				status,
+				notifier,
+			)
+			if err != nil {
+				return err
+			}
 
-		start := time.Now()
-		currentNamespaceToMetrics := newNamespaceToMetrics(config)
+			previousNamespacesToMetrics = currentNamespaceToMetrics
+		}
 
-		workloads, err := client.GetWorkloads(config.IncludeNamespaces, config.IncludeResources, ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get workloads: %w", err)
+		if config.SnapshotInterval > 0 && time.Since(lastSnapshot) >= config.SnapshotInterval {
+			err := exportSnapshot(client, ctx, config)
+			if err != nil {
+				slog.Error("failed to export periodic snapshot", "error", err)
+			}
+
+			lastSnapshot = time.Now()
 		}
 
-		workloads = scalable.FilterExcluded(
-			workloads,
-			config.IncludeLabels,
-			config.ExcludeNamespaces,
-			config.ExcludeWorkloads,
-			currentNamespaceToMetrics,
-		)
-		slog.Info("scanning over workloads matching filters", "amount", len(workloads))
+		if config.Once {
+			slog.Debug("once is set to true, exiting")
+			break
+		}
 
-		namespaceScopes, err := client.GetNamespacesScopes(workloads, ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get namespace annotations: %w", err)
+		slog.Debug("waiting until next scan", "interval", config.Interval.String())
+
+		select {
+		case <-ctx.Done():
+			slog.Info("received shutdown signal, stopping downscaler")
+			return nil
+		case <-time.After(config.Interval):
 		}
+	}
 
-		var waitGroup sync.WaitGroup
-		for _, workload := range workloads {
-			waitGroup.Add(1)
+	return nil
+}
 
-			go func(workload scalable.Workload) {
-				slog.Debug("scanning workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+// refreshGlobalMode gets the current mode of the global switch and announces it if it changed.
+// If the mode can't be retrieved the previous mode is kept.
+func refreshGlobalMode(
+	client kubernetes.Client,
+	ctx context.Context,
+	previousMode values.GlobalMode,
+	downscalerMetrics *metrics.Metrics,
+	config *runtimeConfiguration,
+) values.GlobalMode {
+	globalMode, err := client.GetGlobalMode(ctx)
+	if err != nil {
+		slog.Error("failed to get global mode, keeping the previous mode", "error", err, "mode", previousMode)
+		return previousMode
+	}
 
-				defer waitGroup.Done()
+	if globalMode == previousMode {
+		return globalMode
+	}
 
-				workloadNamespaceMetrics, err := getWorkloadNamespaceMetrics(config, workload, currentNamespaceToMetrics)
-				if err != nil && !errors.Is(err, ErrMetricsDisabled) {
-					slog.Error("failed to get namespace metrics", "error", err, "namespace", workload.GetNamespace())
-					return
-				}
+	slog.Info("global mode changed", "previousMode", previousMode, "mode", globalMode)
+	downscalerMetrics.UpdateGlobalMode(config.MetricsEnabled, string(globalMode))
 
-				err = scanWorkload(workload, client, ctx, scopeDefault, scopeCli, scopeEnv, namespaceScopes, workloadNamespaceMetrics, config)
-				if err != nil {
-					slog.Error("failed to scan workload", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
-					return
-				}
+	err = client.AddGlobalModeChangedEvent(globalMode, ctx)
+	if err != nil {
+		slog.Error("failed to announce global mode change", "error", err)
+	}
+
+	return globalMode
+}
+
+// runMeasuredScanCycle runs a single scan cycle and updates the metrics with its results.
+func runMeasuredScanCycle(
+	client kubernetes.Client,
+	ctx context.Context,
+	scopeDefault, scopeCli, scopeEnv *values.Scope,
+	globalMode values.GlobalMode,
+	previousNamespacesToMetrics map[string]*metrics.NamespaceMetricsHolder,
+	config *runtimeConfiguration,
+	downscalerMetrics *metrics.Metrics,
+	status *scanStatus,
+	notifier *notification.Notifier,
+) (map[string]*metrics.NamespaceMetricsHolder, error) {
+	cycleID := rand.Text()
+
+	slog.Info("scanning workloads", "globalMode", globalMode, "cycleID", cycleID)
+
+	start := time.Now()
+	currentNamespaceToMetrics := newNamespaceToMetrics(config)
+
+	var safetyLimitExceededErr *SafetyLimitExceededError
+
+	status.startCycle(cycleID, globalMode)
+
+	cycleCtx, span := tracing.Start(
+		audit.WithCycleID(ctx, cycleID),
+		"scan cycle",
+		attribute.String("cycle.id", cycleID),
+		attribute.String("cycle.globalMode", string(globalMode)),
+	)
+
+	err := runScanCycle(
+		client,
+		cycleCtx,
+		scopeDefault, scopeCli, scopeEnv,
+		globalMode,
+		currentNamespaceToMetrics,
+		config,
+		status,
+	)
+
+	tracing.End(span, err)
+	status.finishCycle(err)
+
+	notifier.Notify(&notification.Batch{
+		CycleID: cycleID,
+		Time:    time.Now().UTC(),
+		DryRun:  config.DryRun,
+		Events:  status.getNotificationEvents(),
+	})
+
+	downscalerMetrics.UpdateSafetyLimitExceeded(config.MetricsEnabled, errors.As(err, &safetyLimitExceededErr))
+
+	if err != nil {
+		switch {
+		case errors.As(err, &safetyLimitExceededErr):
+			slog.Error("aborted scan cycle, no workloads were scaled", "error", err)
+
+			eventErr := client.AddSafetyLimitExceededEvent(err.Error(), ctx)
+			if eventErr != nil {
+				slog.Error("failed to announce exceeded safety limit", "error", eventErr)
+			}
+		case ctx.Err() != nil:
+			slog.Warn("scan cycle was interrupted", "error", err, "cause", context.Cause(ctx))
+		case errors.Is(err, context.DeadlineExceeded):
+			slog.Error("scan cycle exceeded its deadline, continuing with the next cycle", "error", err, "timeout", config.ScanTimeout.String())
+		default:
+			return nil, err
+		}
+	}
 
-				slog.Debug("successfully scanned workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
-			}(workload)
+	downscalerMetrics.UpdateMetrics(
+		config.MetricsEnabled,
+		currentNamespaceToMetrics,
+		previousNamespacesToMetrics,
+		time.Since(start).Seconds(),
+	)
+
+	if err == nil {
+		downscalerMetrics.UpdateWorkloadMetrics(config.MetricsEnabled, status.getWorkloadSamples(), time.Now())
+	}
+
+	return currentNamespaceToMetrics, nil
+}
+
+// runScanCycle runs a single scan cycle bounded by the scan timeout, if one is set.
+// When ctx is cancelled no new workloads are scanned, but in-flight scalings are given the shutdown grace period to finish.
+// If ctx was cancelled because the leadership was lost, in-flight scalings are cancelled immediately.
+func runScanCycle(
+	client kubernetes.Client,
+	ctx context.Context,
+	scopeDefault, scopeCli, scopeEnv *values.Scope,
+	globalMode values.GlobalMode,
+	currentNamespaceToMetrics map[string]*metrics.NamespaceMetricsHolder,
+	config *runtimeConfiguration,
+	status *scanStatus,
+) error {
+	cycleCtx, cancelCycle := context.WithCancel(context.WithoutCancel(ctx))
+	if config.ScanTimeout > 0 {
+		cycleCtx, cancelCycle = context.WithTimeout(context.WithoutCancel(ctx), config.ScanTimeout)
+	}
+	defer cancelCycle()
+
+	stopGracePeriod := context.AfterFunc(ctx, func() {
+		if errors.Is(context.Cause(ctx), ErrLeadershipLost) {
+			slog.Warn("lost the leadership, cancelling in-flight scalings")
+			cancelCycle()
+
+			return
 		}
 
-		waitGroup.Wait()
-		slog.Info("successfully scanned all workloads")
+		slog.Info("waiting for in-flight scalings to finish", "gracePeriod", config.ShutdownGracePeriod.String())
+
+		time.AfterFunc(config.ShutdownGracePeriod, cancelCycle)
+	})
+	defer stopGracePeriod()
+
+	return scanWorkloads(
+		client,
+		cycleCtx,
+		ctx.Done(),
+		scopeDefault, scopeCli, scopeEnv,
+		globalMode,
+		currentNamespaceToMetrics,
+		config,
+		status,
+	)
+}
 
-		downscalerMetrics.UpdateMetrics(
-			config.MetricsEnabled,
+// scanWorkloads runs a single scan cycle over all workloads using a bounded pool of workers.
+// First the scaling of all workloads is evaluated, then the safety limits are checked and only then the scalings are applied.
+// Workloads which weren't started before the context is done or stop is closed are skipped until the next cycle.
+func scanWorkloads(
+	client kubernetes.Client,
+	ctx context.Context,
+	stop <-chan struct{},
+	scopeDefault, scopeCli, scopeEnv *values.Scope,
+	globalMode values.GlobalMode,
+	currentNamespaceToMetrics map[string]*metrics.NamespaceMetricsHolder,
+	config *runtimeConfiguration,
+	status *scanStatus,
+) error {
+	workloads, err := client.GetWorkloads(config.IncludeNamespaces, config.IncludeResources, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get workloads: %w", err)
+	}
+
+	included := scalable.FilterExcluded(
+		workloads,
+		config.IncludeLabels,
+		config.ExcludeNamespaces,
+		config.ExcludeWorkloads,
+		scalable.NewProtectionRules(&config.CommonRuntimeConfiguration),
+		currentNamespaceToMetrics,
+	)
+	status.recordExcludedWorkloads(workloads, included)
+
+	workloads = included
+	slog.Info("scanning over workloads matching filters", "amount", len(workloads))
+
+	workloads, pairedWorkloads := scalable.PairWorkloads(workloads)
+
+	namespaceScopes, err := client.GetNamespacesScopes(workloads, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace annotations: %w", err)
+	}
+
+	if globalMode.IsActive() {
+		resolveNamespaceWakeRequests(namespaceScopes, client, ctx)
+	}
+
+	var decisionsMutex sync.Mutex
+
+	decisions := make([]*scalingDecision, 0, len(workloads))
+
+	skipped := runWorkerPool(workloads, config.MaxConcurrentScans, stop, ctx, func(workload scalable.Workload) {
+		decision := evaluateQueuedWorkload(
+			workload,
+			pairedWorkloads[workload.GetUID()],
+			client,
+			ctx,
+			scopeDefault, scopeCli, scopeEnv,
+			globalMode,
+			namespaceScopes,
 			currentNamespaceToMetrics,
-			previousNamespacesToMetrics,
-			time.Since(start).Seconds(),
+			config,
+			status,
 		)
+		if decision == nil {
+			return
+		}
 
-		previousNamespacesToMetrics = currentNamespaceToMetrics
+		decisionsMutex.Lock()
+		defer decisionsMutex.Unlock()
 
-		if config.Once {
-			slog.Debug("once is set to true, exiting")
-			break
+		decisions = append(decisions, decision)
+	})
+
+	err = getInterruptionError(ctx, skipped)
+	if err != nil {
+		return err
+	}
+
+	err = checkSafetyLimits(decisions, workloads, config)
+	if err != nil {
+		overridden, overrideErr := client.IsSafetyLimitOverridden(ctx)
+		if overrideErr != nil {
+			slog.Error("failed to check if the safety limits are overridden", "error", overrideErr)
 		}
 
-		slog.Debug("waiting until next scan", "interval", config.Interval.String())
-		time.Sleep(config.Interval)
+		if !overridden {
+			return err
+		}
+
+		slog.Warn("safety limit exceeded, but the safety limits are overridden", "error", err)
 	}
 
+	skipped = runWorkerPool(decisions, config.MaxConcurrentScans, stop, ctx, func(decision *scalingDecision) {
+		applyQueuedDecision(decision, client, ctx, config, status)
+	})
+
+	err = getInterruptionError(ctx, skipped)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("successfully scanned all workloads")
+
 	return nil
 }
 
+// getInterruptionError returns an error if the context is done or items were skipped.
+func getInterruptionError(ctx context.Context, skipped int) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to scan all workloads: %w", err)
+	}
+
+	if skipped > 0 {
+		return newScanInterruptedError(skipped)
+	}
+
+	return nil
+}
+
+// runWorkerPool runs work for all items using a bounded amount of workers and waits until all started work is done.
+// It returns the amount of items which were skipped, because the context was done or stop was closed before they were started.
+func runWorkerPool[T any](items []T, workers int, stop <-chan struct{}, ctx context.Context, work func(T)) int {
+	queue := make(chan T)
+
+	var waitGroup sync.WaitGroup
+	for range min(workers, len(items)) {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for item := range queue {
+				work(item)
+			}
+		}()
+	}
+
+	skipped := enqueue(items, queue, stop, ctx)
+
+	close(queue)
+	waitGroup.Wait()
+
+	return skipped
+}
+
+// enqueue hands the items to the workers until the context is done or stop is closed
+// and returns the amount of items which were skipped.
+func enqueue[T any](items []T, queue chan<- T, stop <-chan struct{}, ctx context.Context) int {
+	for i, item := range items {
+		select {
+		case queue <- item:
+		case <-stop:
+			return len(items) - i
+		case <-ctx.Done():
+			return len(items) - i
+		}
+	}
+
+	return 0
+}
+
+// evaluateQueuedWorkload evaluates a single workload of the scan cycle, logs the result and announces changes of its exclusion.
+// It returns nil if the workload doesn't need to be scaled or its evaluation failed.
+func evaluateQueuedWorkload(
+	workload scalable.Workload,
+	pairedWorkloads []scalable.Workload,
+	client kubernetes.Client,
+	ctx context.Context,
+	scopeDefault, scopeCli, scopeEnv *values.Scope,
+	globalMode values.GlobalMode,
+	namespaceScopes map[string]*values.Scope,
+	currentNamespaceToMetrics map[string]*metrics.NamespaceMetricsHolder,
+	config *runtimeConfiguration,
+	status *scanStatus,
+) *scalingDecision {
+	slog.Debug("scanning workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+	ctx, span := tracing.Start(ctx, "evaluate workload", tracing.WorkloadAttributes(workload)...)
+	defer span.End()
+
+	workloadNamespaceMetrics, err := getWorkloadNamespaceMetrics(config, workload, currentNamespaceToMetrics)
+	if err != nil && !errors.Is(err, ErrMetricsDisabled) {
+		slog.Error("failed to get namespace metrics", "error", err, "namespace", workload.GetNamespace())
+		tracing.RecordError(span, err)
+
+		return nil
+	}
+
+	decision, err := evaluateWorkload(
+		workload,
+		pairedWorkloads,
+		client,
+		ctx,
+		scopeDefault, scopeCli, scopeEnv,
+		globalMode,
+		namespaceScopes,
+		workloadNamespaceMetrics,
+		config,
+	)
+	if err != nil {
+		slog.Error("failed to scan workload", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
+		status.recordError(workload, err)
+		tracing.RecordError(span, err)
+
+		return nil
+	}
+
+	if decision.skipReason != "" {
+		span.SetAttributes(attribute.String("decision.skipReason", decision.skipReason))
+		announceSkipChange(decision, client, ctx, status)
+		status.recordSkipped(workload, decision.skipReason)
+
+		return nil
+	}
+
+	span.SetAttributes(attribute.String("decision.scaling", decision.scaling.String()))
+	announceGracePeriodExpiry(workload, client, ctx, status)
+
+	return decision
+}
+
+// announceSkipChange adds an event if the workload became excluded since the last finished scan cycle.
+// Nothing is announced if the workload wasn't part of the last finished scan cycle, e.g. after a restart.
+func announceSkipChange(decision *scalingDecision, client kubernetes.Client, ctx context.Context, status *scanStatus) {
+	previousDecision, ok := status.getPreviousDecision(decision.workload)
+	if !ok || decision.skipReason != decisionExcluded || previousDecision == decisionExcluded {
+		return
+	}
+
+	kubernetes.NewResourceLoggerForWorkload(client, decision.workload).InfoExcluded(ctx)
+}
+
+// announceGracePeriodExpiry adds an event if the workload was in its grace period in the last finished scan cycle.
+func announceGracePeriodExpiry(workload scalable.Workload, client kubernetes.Client, ctx context.Context, status *scanStatus) {
+	previousDecision, ok := status.getPreviousDecision(workload)
+	if !ok || previousDecision != decisionGracePeriod {
+		return
+	}
+
+	kubernetes.NewResourceLoggerForWorkload(client, workload).InfoGracePeriodExpired(ctx)
+}
+
+// applyQueuedDecision applies a single scaling decision of the scan cycle and logs the result.
+func applyQueuedDecision(
+	decision *scalingDecision,
+	client kubernetes.Client,
+	ctx context.Context,
+	config *runtimeConfiguration,
+	status *scanStatus,
+) {
+	workload := decision.workload
+
+	err := decision.apply(client, ctx, config)
+
+	// the decision is only recorded once it was applied, so cycles aborted by the safety limits don't show up as scaled
+	status.recordDecision(decision)
+
+	if err != nil {
+		slog.Error("failed to scan workload", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
+		status.recordError(workload, err)
+
+		return
+	}
+
+	slog.Debug("successfully scanned workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+}
+
 // attemptScaling handles retries for scaling a workload in case of conflicts.
+//
+//nolint:nonamedreturns // the returned error is recorded on the span
 func attemptScaling(
 	client kubernetes.Client,
 	ctx context.Context,
 	scaling values.Scaling,
 	workload scalable.Workload,
 	scopes values.Scopes,
+	cause string,
 	workloadNamespaceMetrics *metrics.NamespaceMetricsHolder,
 	config *runtimeConfiguration,
-) error {
+) (err error) {
+	attributes := append(tracing.WorkloadAttributes(workload), attribute.String("scaling", scaling.String()))
+
+	ctx, span := tracing.Start(ctx, "scale workload", attributes...)
+	defer func() { tracing.End(span, err) }()
+
 	for retry := range config.MaxRetriesOnConflict + 1 {
-		err := scaleWorkload(scaling, workload, scopes, workloadNamespaceMetrics, client, ctx)
+		attemptCtx, attemptSpan := tracing.Start(ctx, "update workload", attribute.Int("attempt", retry+1))
+
+		err = scaleWorkload(scaling, workload, scopes, cause, workloadNamespaceMetrics, client, attemptCtx, config.DriftPolicy)
+		tracing.End(attemptSpan, err)
+
 		if err != nil {
 			if !strings.Contains(err.Error(), registry.OptimisticLockErrorMsg) {
 				workloadNamespaceMetrics.IncrementGenericErrorsCount()
@@ -300,16 +849,19 @@ func attemptScaling(
 	return newMaxRetriesExceeded(config.MaxRetriesOnConflict)
 }
 
-// scanWorkload runs a scan on the workload, determining the scaling and scaling the workload.
-func scanWorkload(
+// evaluateWorkload parses the scopes of the workload and determines its scaling.
+// If the workload doesn't need to be scaled, the skip reason of the decision is set.
+func evaluateWorkload(
 	workload scalable.Workload,
+	pairedWorkloads []scalable.Workload,
 	client kubernetes.Client,
 	ctx context.Context,
 	scopeDefault, scopeCli, scopeEnv *values.Scope,
+	globalMode values.GlobalMode,
 	namespaceScopes map[string]*values.Scope,
 	workloadNamespaceMetrics *metrics.NamespaceMetricsHolder,
 	config *runtimeConfiguration,
-) error {
+) (*scalingDecision, error) {
 	resourceLogger := kubernetes.NewResourceLoggerForWorkload(client, workload)
 
 	var err error
@@ -323,18 +875,40 @@ func scanWorkload(
 
 	scopeWorkload := values.NewScope()
 	if err = scopeWorkload.GetScopeFromAnnotations(workload.GetAnnotations(), resourceLogger, ctx); err != nil {
-		return fmt.Errorf("failed to parse workload scope from annotations: %w", err)
+		return nil, fmt.Errorf("failed to parse workload scope from annotations: %w", err)
+	}
+
+	if globalMode.IsActive() {
+		if err = resolveWakeRequest(workload, scopeWorkload, client, ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	scopeNamespace, exists := namespaceScopes[workload.GetNamespace()]
 	if !exists {
-		return newNamespaceScopeRetrieveError(workload.GetNamespace())
+		return nil, newNamespaceScopeRetrieveError(workload.GetNamespace())
 	}
 
 	scopes := values.Scopes{scopeWorkload, scopeNamespace, scopeCli, scopeEnv, scopeDefault}
 
 	slog.Debug("finished parsing all scopes", "scopes", scopes, "workload", workload.GetName(), "namespace", workload.GetNamespace())
 
+	decision := &scalingDecision{
+		workload:                 workload,
+		pairedWorkloads:          pairedWorkloads,
+		scopes:                   scopes,
+		workloadNamespaceMetrics: workloadNamespaceMetrics,
+	}
+
+	if globalMode == values.GlobalModeUpscale {
+		slog.Debug("upscaling workload because of the global mode", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+		decision.scaling = values.ScalingUp
+		decision.cause = "the global mode is set to upscale"
+
+		return decision, nil
+	}
+
 	isInGracePeriod, err := scopes.IsInGracePeriod(
 		config.TimeAnnotation,
 		workload.GetAnnotations(),
@@ -344,14 +918,16 @@ func scanWorkload(
 	)
 	if err != nil {
 		workloadNamespaceMetrics.IncrementExcludedWorkloadsCount()
-		return fmt.Errorf("failed to get if workload is on grace period: %w", err)
+		return nil, fmt.Errorf("failed to get if workload is on grace period: %w", err)
 	}
 
 	if isInGracePeriod {
 		slog.Debug("workload is on grace period, skipping", "workload", workload.GetName(), "namespace", workload.GetNamespace())
 		workloadNamespaceMetrics.IncrementExcludedWorkloadsCount()
 
-		return nil
+		decision.skipReason = decisionGracePeriod
+
+		return decision, nil
 	}
 
 	excluded := scopes.GetExcluded(scopes)
@@ -361,28 +937,107 @@ func scanWorkload(
 		slog.Debug("workload is excluded, skipping", "workload", workload.GetName(), "namespace", workload.GetNamespace())
 		workloadNamespaceMetrics.IncrementExcludedWorkloadsCount()
 
-		return nil
+		decision.skipReason = decisionExcluded
+
+		return decision, nil
+	}
+
+	decision.scaling = getCurrentScaling(workload, excluded, upscaleOnExclusion, &scopes)
+	decision.cause = getScalingCause(excluded, scopes)
+
+	return decision, nil
+}
+
+// apply scales the workload, its routes, its paired workloads and if enabled its children to the decided scaling.
+func (d *scalingDecision) apply(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) error {
+	if scopeID, ok := d.scopes.GetDecidingScope(); ok {
+		ctx = audit.WithDecidingScope(ctx, scopeID.String())
 	}
 
-	scaling := getCurrentScaling(workload, excluded, upscaleOnExclusion, &scopes)
+	wasDownscaled := scalable.GetOriginalState(d.workload) != nil
 
-	err = attemptScaling(client, ctx, scaling, workload, scopes, workloadNamespaceMetrics, config)
+	err := attemptScaling(client, ctx, d.scaling, d.workload, d.scopes, d.cause, d.workloadNamespaceMetrics, config)
 	if err != nil {
 		return fmt.Errorf("failed to scale workload: %w", err)
 	}
 
-	if scopes.GetScaleChildren() {
-		childrenWorkloads, err := client.GetChildrenWorkloads(workload, ctx)
+	isDownscaled := scalable.GetOriginalState(d.workload) != nil
+
+	err = updateMaintenanceRedirect(d.scaling, d.workload, isDownscaled != wasDownscaled, client, ctx, config)
+	if err != nil {
+		return err
+	}
+
+	scaleWorkloads(d.scaling, d.pairedWorkloads, d.scopes, d.cause, d.workloadNamespaceMetrics, client, ctx, config)
+
+	if d.scopes.GetScaleChildren() {
+		childrenWorkloads, err := client.GetChildrenWorkloads(d.workload, ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get children workloads: %w", err)
 		}
 
-		scaleWorkloads(scaling, childrenWorkloads, scopes, workloadNamespaceMetrics, client, ctx, config)
+		scaleWorkloads(d.scaling, childrenWorkloads, d.scopes, d.cause, d.workloadNamespaceMetrics, client, ctx, config)
+	}
+
+	return nil
+}
+
+// updateMaintenanceRedirect redirects the routes of a downscaled workload to the maintenance service and restores them on upscale.
+func updateMaintenanceRedirect(
+	scaling values.Scaling,
+	workload scalable.Workload,
+	changed bool,
+	client kubernetes.Client,
+	ctx context.Context,
+	config *runtimeConfiguration,
+) error {
+	//nolint:gosec // the port is validated to be in the uint16 range on startup
+	maintenanceService := kubernetes.NewMaintenanceService(config.MaintenanceService, int32(config.MaintenanceServicePort))
+	if maintenanceService == nil {
+		return nil
+	}
+
+	// the routes only need to be updated if the scaling changed the workload or their last update failed,
+	// listing them on every cycle is expensive
+	if !changed && !client.HasPendingRouteUpdate(workload) {
+		return nil
+	}
+
+	if scaling == values.ScalingDown {
+		slog.Debug("redirecting routes to maintenance service", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+		err := client.RedirectRoutes(workload, maintenanceService, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to redirect routes: %w", err)
+		}
+	}
+
+	if scaling == values.ScalingUp {
+		slog.Debug("restoring routes from maintenance service", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+
+		err := client.RestoreRoutes(workload, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to restore routes: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// getScalingCause describes why the workload is scaled for the scaling events.
+func getScalingCause(excluded bool, scopes values.Scopes) string {
+	if excluded {
+		return "the workload is excluded and excluded workloads are upscaled"
+	}
+
+	scopeID, ok := scopes.GetDecidingScope()
+	if !ok {
+		return ""
+	}
+
+	return "decided by " + scopeID.String()
+}
+
 func getCurrentScaling(workload scalable.Workload, excluded, upscaleOnExclusion bool, scopes *values.Scopes) values.Scaling {
 	if upscaleOnExclusion && excluded {
 		slog.Debug("upscaling excluded workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
@@ -393,24 +1048,32 @@ func getCurrentScaling(workload scalable.Workload, excluded, upscaleOnExclusion
 	return scopes.GetCurrentScaling()
 }
 
-// scaleWorkloads scales the given workloads to the specified scaling asynchronously.
+// scaleWorkloads scales the given workloads to the specified scaling concurrently and waits until all of them are done.
 func scaleWorkloads(
 	scaling values.Scaling,
 	workloads []scalable.Workload,
 	scopes values.Scopes,
+	cause string,
 	workloadNamespaceMetrics *metrics.NamespaceMetricsHolder,
 	client kubernetes.Client,
 	ctx context.Context,
 	config *runtimeConfiguration,
 ) {
+	var waitGroup sync.WaitGroup
 	for _, workload := range workloads {
+		waitGroup.Add(1)
+
 		go func(workload scalable.Workload) {
-			err := attemptScaling(client, ctx, scaling, workload, scopes, workloadNamespaceMetrics, config)
+			defer waitGroup.Done()
+
+			err := attemptScaling(client, ctx, scaling, workload, scopes, cause, workloadNamespaceMetrics, config)
 			if err != nil {
 				slog.Error("failed to scale workload", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
 			}
 		}(workload)
 	}
+
+	waitGroup.Wait()
 }
 
 // scaleWorkload scales the given workload according to the given wanted scaling state.
@@ -418,9 +1081,11 @@ func scaleWorkload(
 	scaling values.Scaling,
 	workload scalable.Workload,
 	scopes values.Scopes,
+	cause string,
 	workloadNamespaceMetrics *metrics.NamespaceMetricsHolder,
 	client kubernetes.Client,
 	ctx context.Context,
+	driftPolicy values.DriftPolicy,
 ) error {
 	if scaling == values.ScalingNone {
 		slog.Debug("scaling is not set by any scope, skipping", "workload", workload.GetName(), "namespace", workload.GetNamespace())
@@ -454,6 +1119,19 @@ setting different scaling states at the same time (e.g. downtime-period and upti
 	}
 
 	if scaling == values.ScalingDown {
+		// manual changes only matter while the workload stays downscaled, upscaling restores the original replicas anyway
+		drift, err := client.RepairDrift(workload, driftPolicy, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to handle manual changes: %w", err)
+		}
+
+		if drift != nil && driftPolicy == values.DriftPolicyIgnore {
+			slog.Debug("workload was manually changed, leaving it alone", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+			workloadNamespaceMetrics.IncrementExcludedWorkloadsCount()
+
+			return nil
+		}
+
 		slog.Debug("downscaling workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
 
 		downscaleReplicas, err := scopes.GetDownscaleReplicas()
@@ -461,7 +1139,7 @@ setting different scaling states at the same time (e.g. downtime-period and upti
 			return fmt.Errorf("failed to get downscale replicas: %w", err)
 		}
 
-		savedResources, err := client.DownscaleWorkload(downscaleReplicas, workload, ctx)
+		savedResources, err := client.DownscaleWorkload(downscaleReplicas, workload, cause, ctx)
 		if err != nil {
 			return fmt.Errorf("failed to downscale workload: %w", err)
 		}
@@ -473,7 +1151,7 @@ setting different scaling states at the same time (e.g. downtime-period and upti
 	if scaling == values.ScalingUp {
 		slog.Debug("upscaling workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
 
-		err := client.UpscaleWorkload(workload, ctx)
+		err := client.UpscaleWorkload(workload, cause, ctx)
 		if err != nil {
 			return fmt.Errorf("failed to upscale workload: %w", err)
 		}
@@ -493,6 +1171,10 @@ func initMetrics(config *runtimeConfiguration) *metrics.Metrics {
 
 	m := metrics.NewMetrics(config.DryRun)
 	m.RegisterAll()
+
+	if config.WorkloadMetrics {
+		m.EnableWorkloadMetrics(config.DryRun, config.WorkloadMetricsNamespaces)
+	}
 	slog.Info("metrics initialized")
 
 	return m
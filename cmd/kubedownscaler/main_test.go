@@ -10,9 +10,11 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type MockClient struct {
@@ -28,17 +30,73 @@ func (m *MockClient) GetNamespaceAnnotations(namespace string, ctx context.Conte
 func (m *MockClient) DownscaleWorkload(
 	replicas values.Replicas,
 	workload scalable.Workload,
+	cause string,
 	ctx context.Context,
 ) (*metrics.SavedResources, error) {
-	args := m.Called(replicas, workload, ctx)
+	args := m.Called(replicas, workload, cause, ctx)
 	return args.Get(0).(*metrics.SavedResources), args.Error(1)
 }
 
-func (m *MockClient) UpscaleWorkload(workload scalable.Workload, ctx context.Context) error {
+func (m *MockClient) UpscaleWorkload(workload scalable.Workload, cause string, ctx context.Context) error {
+	args := m.Called(workload, cause, ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) RepairDrift(workload scalable.Workload, policy values.DriftPolicy, ctx context.Context) (*scalable.Drift, error) {
+	args := m.Called(workload, policy, ctx)
+	return args.Get(0).(*scalable.Drift), args.Error(1)
+}
+
+func (m *MockClient) RedirectRoutes(workload scalable.Workload, service *client.MaintenanceService, ctx context.Context) error {
+	args := m.Called(workload, service, ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) RestoreRoutes(workload scalable.Workload, ctx context.Context) error {
 	args := m.Called(workload, ctx)
 	return args.Error(0)
 }
 
+func (m *MockClient) HasPendingRouteUpdate(workload scalable.Workload) bool {
+	args := m.Called(workload)
+	return args.Bool(0)
+}
+
+func (m *MockClient) GetNamespacesScopes(workloads []scalable.Workload, ctx context.Context) (map[string]*values.Scope, error) {
+	args := m.Called(workloads, ctx)
+	return args.Get(0).(map[string]*values.Scope), args.Error(1)
+}
+
+func (m *MockClient) IsSafetyLimitOverridden(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClient) CleanupWorkload(workload scalable.Workload, ctx context.Context) (bool, error) {
+	args := m.Called(workload, ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClient) StopDeployment(deployment string, ctx context.Context) error {
+	args := m.Called(deployment, ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) DeleteWebhookConfiguration(name string, ctx context.Context) error {
+	args := m.Called(name, ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) CheckConnection(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) GetWorkloads(namespaces, resourceTypes []string, ctx context.Context) ([]scalable.Workload, error) {
+	args := m.Called(namespaces, resourceTypes, ctx)
+	return args.Get(0).([]scalable.Workload), args.Error(1)
+}
+
 type MockWorkload struct {
 	scalable.Workload
 	mock.Mock
@@ -64,6 +122,10 @@ func (m *MockWorkload) GetCreationTimestamp() v1.Time {
 	return v1.Time{Time: args.Get(0).(time.Time)}
 }
 
+func (m *MockWorkload) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+}
+
 func TestScanWorkload(t *testing.T) {
 	t.Parallel()
 
@@ -96,11 +158,230 @@ func TestScanWorkload(t *testing.T) {
 	mockWorkload.On("GetAnnotations").Return(map[string]string{
 		"downscaler/force-downtime": "true",
 	})
-	mockClient.On("DownscaleWorkload", values.AbsoluteReplicas(0), mockWorkload, ctx).Return(metrics.NewSavedResources(0, 0), nil)
-	err := scanWorkload(mockWorkload, mockClient, ctx, values.GetDefaultScope(), scopeCli, scopeEnv, namespaceScopes, namespaceMetrics, config)
+	mockClient.On("RepairDrift", mockWorkload, config.DriftPolicy, mock.Anything).Return((*scalable.Drift)(nil), nil)
+	mockClient.On("DownscaleWorkload", values.AbsoluteReplicas(0), mockWorkload, "decided by ScopeWorkload", mock.Anything).
+		Return(metrics.NewSavedResources(0, 0), nil)
+	decision, err := evaluateWorkload(
+		mockWorkload,
+		nil,
+		mockClient,
+		ctx,
+		values.GetDefaultScope(), scopeCli, scopeEnv,
+		values.GlobalModeResume,
+		namespaceScopes,
+		namespaceMetrics,
+		config,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, decision)
 
+	err = decision.apply(mockClient, ctx, config)
 	require.NoError(t, err)
 
 	mockClient.AssertExpectations(t)
 	mockWorkload.AssertExpectations(t)
 }
+
+func TestApplyUpdatesRoutesOnlyOnChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		annotations   string
+		wantRedirects int
+	}{
+		{
+			name:          "downscaling redirects the routes",
+			annotations:   `{}`,
+			wantRedirects: 1,
+		},
+		{
+			name:          "already downscaled workload keeps its routes",
+			annotations:   `{"downscaler/original-replicas": "3"}`,
+			wantRedirects: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			config := getDefaultConfig()
+			config.MaintenanceService = "sleeping"
+
+			workload := newDeploymentFromJSON(t, `{
+				"metadata": {"name": "web", "namespace": "default", "annotations": `+test.annotations+`},
+				"spec": {"replicas": 3}
+			}`)
+
+			mockClient := new(MockClient)
+			mockClient.On("RepairDrift", workload, config.DriftPolicy, mock.Anything).Return((*scalable.Drift)(nil), nil)
+			mockClient.On("DownscaleWorkload", values.AbsoluteReplicas(0), workload, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					_, _, err := workload.ScaleDown(args.Get(0).(values.Replicas))
+					assert.NoError(t, err)
+				}).
+				Return(metrics.NewSavedResources(0, 0), nil)
+			mockClient.On("RedirectRoutes", workload, mock.Anything, mock.Anything).Return(nil)
+			mockClient.On("HasPendingRouteUpdate", workload).Return(false)
+
+			scopes := values.Scopes{values.NewScope(), values.NewScope(), values.NewScope(), values.NewScope(), values.GetDefaultScope()}
+			decision := &scalingDecision{
+				workload:                 workload,
+				scopes:                   scopes,
+				scaling:                  values.ScalingDown,
+				workloadNamespaceMetrics: &metrics.NamespaceMetricsHolder{},
+			}
+
+			require.NoError(t, decision.apply(mockClient, ctx, config))
+			mockClient.AssertNumberOfCalls(t, "RedirectRoutes", test.wantRedirects)
+		})
+	}
+}
+
+func TestApplyRetriesFailedRouteRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	config.MaintenanceService = "sleeping"
+
+	workload := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "web", "namespace": "default", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 0}
+	}`)
+
+	mockClient := new(MockClient)
+	mockClient.On("UpscaleWorkload", workload, mock.Anything, mock.Anything).
+		Run(func(_ mock.Arguments) {
+			_, err := workload.ScaleUp()
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+	mockClient.On("RestoreRoutes", workload, mock.Anything).Return(assert.AnError).Once()
+	mockClient.On("RestoreRoutes", workload, mock.Anything).Return(nil).Once()
+	mockClient.On("HasPendingRouteUpdate", workload).Return(true).Once()
+	mockClient.On("HasPendingRouteUpdate", workload).Return(false)
+
+	scopes := values.Scopes{values.NewScope(), values.NewScope(), values.NewScope(), values.NewScope(), values.GetDefaultScope()}
+	decision := &scalingDecision{
+		workload:                 workload,
+		scopes:                   scopes,
+		scaling:                  values.ScalingUp,
+		workloadNamespaceMetrics: &metrics.NamespaceMetricsHolder{},
+	}
+
+	require.Error(t, decision.apply(mockClient, ctx, config), "the first restore fails")
+	require.NoError(t, decision.apply(mockClient, ctx, config), "the next cycle retries the restore")
+	require.NoError(t, decision.apply(mockClient, ctx, config), "restored routes aren't updated again")
+
+	mockClient.AssertNumberOfCalls(t, "RestoreRoutes", 2)
+}
+
+func TestScaleWorkloadChecksDriftOnlyOnDownscale(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	workload := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "web", "namespace": "default", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 1}
+	}`)
+
+	mockClient := new(MockClient)
+	mockClient.On("UpscaleWorkload", workload, "", ctx).Return(nil)
+
+	err := scaleWorkload(values.ScalingUp, workload, values.Scopes{}, "", &metrics.NamespaceMetricsHolder{}, mockClient, ctx,
+		values.DriftPolicyRedownscale)
+	require.NoError(t, err)
+
+	mockClient.AssertNotCalled(t, "RepairDrift", mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Parallel()
+
+	workloads := []scalable.Workload{new(MockWorkload), new(MockWorkload), new(MockWorkload)}
+
+	t.Run("all workloads are queued", func(t *testing.T) {
+		t.Parallel()
+
+		workloadQueue := make(chan scalable.Workload, len(workloads))
+
+		skipped := enqueue(workloads, workloadQueue, nil, t.Context())
+
+		require.Equal(t, 0, skipped)
+		require.Len(t, workloadQueue, len(workloads))
+	})
+
+	t.Run("remaining workloads are skipped after the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		workloadQueue := make(chan scalable.Workload, 1)
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		skipped := enqueue(workloads, workloadQueue, nil, ctx)
+
+		require.Equal(t, len(workloads)-1, skipped)
+	})
+	t.Run("remaining workloads are skipped after stop is closed", func(t *testing.T) {
+		t.Parallel()
+
+		stop := make(chan struct{})
+		workloadQueue := make(chan scalable.Workload, 2)
+
+		time.AfterFunc(10*time.Millisecond, func() { close(stop) })
+
+		skipped := enqueue(workloads, workloadQueue, stop, t.Context())
+
+		require.Equal(t, len(workloads)-2, skipped)
+	})
+}
+
+func TestRunScanCycleCancelsOnLostLeadership(t *testing.T) {
+	t.Parallel()
+
+	config := getDefaultConfig()
+	config.ShutdownGracePeriod = time.Hour
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+
+	mockClient := new(MockClient)
+	mockClient.On("GetWorkloads", config.IncludeNamespaces, config.IncludeResources, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cancel(ErrLeadershipLost)
+			<-args.Get(2).(context.Context).Done()
+		}).
+		Return([]scalable.Workload{}, context.Canceled)
+
+	done := make(chan error)
+
+	go func() {
+		done <- runScanCycle(mockClient, ctx, nil, nil, nil, values.GlobalModeResume, nil, config, newScanStatus())
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight scan wasn't cancelled after the leadership was lost")
+	}
+}
+
+func TestGetScalingCause(t *testing.T) {
+	t.Parallel()
+
+	scopeNamespace := values.NewScope()
+	require.NoError(t, scopeNamespace.DownTime.Set("always"))
+
+	scopes := values.Scopes{values.NewScope(), scopeNamespace, values.NewScope(), values.NewScope(), values.GetDefaultScope()}
+
+	assert.Equal(t, "decided by ScopeNamespace", getScalingCause(false, scopes))
+	assert.Equal(t, "the workload is excluded and excluded workloads are upscaled", getScalingCause(true, scopes))
+
+	unset := values.Scopes{values.NewScope(), values.NewScope(), values.NewScope(), values.NewScope(), values.GetDefaultScope()}
+	assert.Empty(t, getScalingCause(false, unset))
+}
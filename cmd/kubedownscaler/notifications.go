@@ -0,0 +1,116 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+// getNotificationEvents gets the changes of the namespaces between the previous and the current scan cycle.
+// A namespace transitioned if all of its scaled workloads are downscaled or upscaled now, but weren't in the previous cycle.
+// Transitions are only announced for namespaces which were part of the previous cycle, e.g. not after a restart.
+// Failures are announced for workloads which didn't fail in the previous cycle.
+func getNotificationEvents(previous, current map[string]*workloadStatus) []notification.Event {
+	previousNamespaces := groupByNamespace(previous)
+	currentNamespaces := groupByNamespace(current)
+
+	var events []notification.Event
+
+	for namespace, workloads := range currentNamespaces {
+		previousWorkloads, existed := previousNamespaces[namespace]
+
+		currentState := getNamespaceState(workloads)
+		if existed && currentState != "" && currentState != getNamespaceState(previousWorkloads) {
+			events = append(events, newNamespaceEvent(namespace, currentState, workloads))
+		}
+
+		var failed []notification.Workload
+
+		for _, status := range workloads {
+			if status.LastError == "" {
+				continue
+			}
+
+			if previousStatus, ok := previous[getStatusKeyOf(status)]; ok && previousStatus.LastError != "" {
+				continue
+			}
+
+			failed = append(failed, notification.Workload{
+				Kind:    status.Kind,
+				Name:    status.Name,
+				Scaling: status.Decision,
+				Error:   status.LastError,
+			})
+		}
+
+		if len(failed) > 0 {
+			events = append(events, notification.Event{Type: notification.EventScalingFailed, Namespace: namespace, Workloads: failed})
+		}
+	}
+
+	slices.SortFunc(events, func(a, b notification.Event) int {
+		return strings.Compare(a.Namespace+"/"+a.Type, b.Namespace+"/"+b.Type)
+	})
+
+	return events
+}
+
+// groupByNamespace groups the workloads by their namespace, sorted by kind and name.
+func groupByNamespace(workloads map[string]*workloadStatus) map[string][]*workloadStatus {
+	namespaces := make(map[string][]*workloadStatus)
+
+	for _, status := range workloads {
+		namespaces[status.Namespace] = append(namespaces[status.Namespace], status)
+	}
+
+	for _, statuses := range namespaces {
+		slices.SortFunc(statuses, func(a, b *workloadStatus) int {
+			return strings.Compare(a.Kind+"/"+a.Name, b.Kind+"/"+b.Name)
+		})
+	}
+
+	return namespaces
+}
+
+// getNamespaceState gets the event type if all scaled workloads of the namespace are downscaled or upscaled.
+// It returns an empty string if the namespace is partially downscaled or none of its workloads are scaled.
+func getNamespaceState(workloads []*workloadStatus) string {
+	var downscaled, upscaled int
+
+	for _, status := range workloads {
+		switch {
+		case status.LastError != "":
+			return ""
+		case status.Decision == values.ScalingDown.String():
+			downscaled++
+		case status.Decision == values.ScalingUp.String():
+			upscaled++
+		}
+	}
+
+	switch {
+	case downscaled > 0 && upscaled == 0:
+		return notification.EventNamespaceDownscaled
+	case upscaled > 0 && downscaled == 0:
+		return notification.EventNamespaceUpscaled
+	default:
+		return ""
+	}
+}
+
+// newNamespaceEvent creates an event announcing the transition of the namespace with its scaled workloads.
+func newNamespaceEvent(namespace, eventType string, workloads []*workloadStatus) notification.Event {
+	event := notification.Event{Type: eventType, Namespace: namespace}
+
+	for _, status := range workloads {
+		if status.Decision != values.ScalingDown.String() && status.Decision != values.ScalingUp.String() {
+			continue
+		}
+
+		event.Workloads = append(event.Workloads, notification.Workload{Kind: status.Kind, Name: status.Name, Scaling: status.Decision})
+	}
+
+	return event
+}
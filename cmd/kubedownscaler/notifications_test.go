@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatusMap(statuses ...*workloadStatus) map[string]*workloadStatus {
+	result := make(map[string]*workloadStatus, len(statuses))
+	for _, status := range statuses {
+		result[getStatusKeyOf(status)] = status
+	}
+
+	return result
+}
+
+func TestGetNotificationEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		previous map[string]*workloadStatus
+		current  map[string]*workloadStatus
+		want     []notification.Event
+	}{
+		{
+			name: "namespace went to sleep",
+			previous: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "up"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "b", Decision: "up"},
+			),
+			current: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "down"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "b", Decision: "down"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "c", Decision: decisionExcluded},
+			),
+			want: []notification.Event{{
+				Type:      notification.EventNamespaceDownscaled,
+				Namespace: "team-a",
+				Workloads: []notification.Workload{
+					{Kind: "Deployment", Name: "a", Scaling: "down"},
+					{Kind: "Deployment", Name: "b", Scaling: "down"},
+				},
+			}},
+		},
+		{
+			name: "partially downscaled namespace",
+			previous: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "up"},
+			),
+			current: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "down"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "b", Decision: "up"},
+			),
+			want: nil,
+		},
+		{
+			name:     "no transition without a previous cycle",
+			previous: newStatusMap(),
+			current: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "down"},
+			),
+			want: nil,
+		},
+		{
+			name: "wake up failed",
+			previous: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "down"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "b", Decision: "up", LastError: "conflict"},
+			),
+			current: newStatusMap(
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "a", Decision: "up", LastError: "forbidden"},
+				&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "b", Decision: "up", LastError: "conflict"},
+			),
+			want: []notification.Event{{
+				Type:      notification.EventScalingFailed,
+				Namespace: "team-a",
+				Workloads: []notification.Workload{{Kind: "Deployment", Name: "a", Scaling: "up", Error: "forbidden"}},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := getNotificationEvents(test.previous, test.current)
+			require.Len(t, got, len(test.want))
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
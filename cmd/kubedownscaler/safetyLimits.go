@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+// scalingDecision is the evaluated scaling of a workload, which is applied after the safety limits of the cycle were checked.
+type scalingDecision struct {
+	workload                 scalable.Workload
+	pairedWorkloads          []scalable.Workload
+	scopes                   values.Scopes
+	scaling                  values.Scaling
+	cause                    string // why the workload is scaled, added to the scaling events
+	skipReason               string // set if the workload isn't scaled, e.g. because it is excluded
+	workloadNamespaceMetrics *metrics.NamespaceMetricsHolder
+}
+
+// isDownscalingChange checks if applying the decision would downscale the workload by downscaling a copy of it.
+// Upscaling is never limited, since it only restores the original state of the workload.
+func (d *scalingDecision) isDownscalingChange() bool {
+	if d.scaling != values.ScalingDown {
+		return false
+	}
+
+	downscaleReplicas, err := d.scopes.GetDownscaleReplicas()
+	if err != nil {
+		return false
+	}
+
+	workloadCopy, err := d.workload.Copy()
+	if err != nil {
+		slog.Warn("failed to copy workload, assuming it would be changed", "error", err, "workload", d.workload.GetName())
+		return true
+	}
+
+	_, changed, err := workloadCopy.ScaleDown(downscaleReplicas)
+	if err != nil {
+		return false
+	}
+
+	return changed
+}
+
+// checkSafetyLimits checks if the decisions would downscale more workloads than allowed per cycle or per namespace.
+func checkSafetyLimits(decisions []*scalingDecision, workloads []scalable.Workload, config *runtimeConfiguration) error {
+	if !config.MaxChangesPerCycle.IsSet() && !config.MaxChangesPerNamespace.IsSet() {
+		return nil
+	}
+
+	namespaceTotals := make(map[string]int)
+	for _, workload := range workloads {
+		namespaceTotals[workload.GetNamespace()]++
+	}
+
+	changes := 0
+	namespaceChanges := make(map[string]int)
+
+	for _, decision := range decisions {
+		if !decision.isDownscalingChange() {
+			continue
+		}
+
+		changes++
+		namespaceChanges[decision.workload.GetNamespace()]++
+	}
+
+	slog.Debug("checking safety limits", "changes", changes, "workloads", len(workloads))
+
+	if config.MaxChangesPerCycle.IsExceeded(changes, len(workloads)) {
+		return newSafetyLimitExceededError("cycle", changes, len(workloads), config.MaxChangesPerCycle.String())
+	}
+
+	namespaces := make([]string, 0, len(namespaceChanges))
+	for namespace := range namespaceChanges {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		if config.MaxChangesPerNamespace.IsExceeded(namespaceChanges[namespace], namespaceTotals[namespace]) {
+			return newSafetyLimitExceededError(
+				fmt.Sprintf("namespace %q", namespace),
+				namespaceChanges[namespace],
+				namespaceTotals[namespace],
+				config.MaxChangesPerNamespace.String(),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWorkload is a workload which reports a change on downscale if it isn't downscaled yet.
+type fakeWorkload struct {
+	scalable.Workload
+	namespace  string
+	downscaled bool
+}
+
+func (f *fakeWorkload) GetNamespace() string {
+	return f.namespace
+}
+
+func (f *fakeWorkload) Copy() (scalable.Workload, error) {
+	workloadCopy := *f
+	return &workloadCopy, nil
+}
+
+func (f *fakeWorkload) ScaleDown(_ values.Replicas) (*metrics.SavedResources, bool, error) {
+	return metrics.NewSavedResources(0, 0), !f.downscaled, nil
+}
+
+func TestCheckSafetyLimits(t *testing.T) {
+	t.Parallel()
+
+	workloads := []scalable.Workload{
+		&fakeWorkload{namespace: "team-a"},
+		&fakeWorkload{namespace: "team-a"},
+		&fakeWorkload{namespace: "team-a", downscaled: true},
+		&fakeWorkload{namespace: "team-b"},
+	}
+
+	newDecisions := func(scaling values.Scaling) []*scalingDecision {
+		decisions := make([]*scalingDecision, 0, len(workloads))
+		for _, workload := range workloads {
+			decisions = append(decisions, &scalingDecision{
+				workload: workload,
+				scopes:   values.Scopes{values.GetDefaultScope()},
+				scaling:  scaling,
+			})
+		}
+
+		return decisions
+	}
+
+	tests := []struct {
+		name                   string
+		scaling                values.Scaling
+		maxChangesPerCycle     string
+		maxChangesPerNamespace string
+		wantErr                bool
+	}{
+		{
+			name:    "no limits",
+			scaling: values.ScalingDown,
+		},
+		{
+			name:               "cycle limit not exceeded",
+			scaling:            values.ScalingDown,
+			maxChangesPerCycle: "3",
+		},
+		{
+			name:               "cycle limit exceeded",
+			scaling:            values.ScalingDown,
+			maxChangesPerCycle: "50%",
+			wantErr:            true,
+		},
+		{
+			name:                   "namespace limit exceeded",
+			scaling:                values.ScalingDown,
+			maxChangesPerNamespace: "1",
+			wantErr:                true,
+		},
+		{
+			name:                   "upscaling is not limited",
+			scaling:                values.ScalingUp,
+			maxChangesPerCycle:     "0",
+			maxChangesPerNamespace: "0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &runtimeConfiguration{}
+
+			if test.maxChangesPerCycle != "" {
+				require.NoError(t, config.MaxChangesPerCycle.Set(test.maxChangesPerCycle))
+			}
+
+			if test.maxChangesPerNamespace != "" {
+				require.NoError(t, config.MaxChangesPerNamespace.Set(test.maxChangesPerNamespace))
+			}
+
+			err := checkSafetyLimits(newDecisions(test.scaling), workloads, config)
+			if !test.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			var safetyLimitExceededErr *SafetyLimitExceededError
+			assert.ErrorAs(t, err, &safetyLimitExceededErr)
+		})
+	}
+}
+
+func TestAbortedCycleKeepsStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	require.NoError(t, config.MaxChangesPerCycle.Set("1"))
+
+	workloads := []scalable.Workload{
+		newDeploymentFromJSON(t, `{
+			"kind": "Deployment",
+			"metadata": {"name": "a", "namespace": "team-a", "annotations": {"downscaler/force-downtime": "true"}},
+			"spec": {"replicas": 2}
+		}`),
+		newDeploymentFromJSON(t, `{
+			"kind": "Deployment",
+			"metadata": {"name": "b", "namespace": "team-a", "annotations": {"downscaler/force-downtime": "true"}},
+			"spec": {"replicas": 2}
+		}`),
+	}
+
+	status := newScanStatus()
+	status.startCycle("upscaled", values.GlobalModeResume)
+
+	for _, workload := range workloads {
+		status.recordDecision(&scalingDecision{
+			workload: workload,
+			scopes:   values.Scopes{values.NewScope(), values.NewScope(), values.NewScope(), values.NewScope(), values.GetDefaultScope()},
+			scaling:  values.ScalingUp,
+		})
+	}
+
+	status.finishCycle(nil)
+
+	previousWorkloads := status.getStatus(nil).Workloads
+
+	mockClient := new(MockClient)
+	mockClient.On("GetWorkloads", config.IncludeNamespaces, config.IncludeResources, mock.Anything).Return(workloads, nil)
+	mockClient.On("GetNamespacesScopes", mock.Anything, mock.Anything).
+		Return(map[string]*values.Scope{"team-a": values.NewScope()}, nil)
+	mockClient.On("IsSafetyLimitOverridden", mock.Anything).Return(false, nil)
+
+	status.startCycle("aborted", values.GlobalModeResume)
+
+	err := scanWorkloads(mockClient, ctx, nil, values.GetDefaultScope(), values.NewScope(), values.NewScope(),
+		values.GlobalModeResume, newNamespaceToMetrics(config), config, status)
+
+	var safetyLimitExceededErr *SafetyLimitExceededError
+	require.ErrorAs(t, err, &safetyLimitExceededErr)
+
+	status.finishCycle(err)
+
+	result := status.getStatus(nil)
+	assert.Equal(t, "aborted", result.LastCycle.ID)
+	assert.Zero(t, result.LastCycle.Downscaled)
+	assert.Equal(t, previousWorkloads, result.Workloads)
+	assert.Empty(t, status.getNotificationEvents())
+	mockClient.AssertNotCalled(t, "DownscaleWorkload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+)
+
+const (
+	configMapLocationPrefix = "configmap:"
+	snapshotConfigMapKey    = "snapshot.json"
+	snapshotFileMode        = 0o600
+)
+
+// snapshot holds the original state of all downscaled workloads, so they can be upscaled even if their annotations were lost.
+type snapshot struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Workloads []snapshotEntry `json:"workloads"`
+}
+
+// snapshotEntry holds the original state of a single downscaled workload.
+type snapshotEntry struct {
+	Resource    string            `json:"resource"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// createSnapshot collects the original state of all downscaled workloads of the included resources.
+func createSnapshot(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) (*snapshot, error) {
+	result := &snapshot{CreatedAt: time.Now().UTC(), Workloads: []snapshotEntry{}}
+
+	for _, resource := range config.IncludeResources {
+		workloads, err := client.GetWorkloads(config.IncludeNamespaces, []string{resource}, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workloads: %w", err)
+		}
+
+		for _, workload := range workloads {
+			state := scalable.GetOriginalState(workload)
+			if state == nil {
+				continue
+			}
+
+			result.Workloads = append(result.Workloads, snapshotEntry{
+				Resource:    resource,
+				Namespace:   workload.GetNamespace(),
+				Name:        workload.GetName(),
+				Annotations: state,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// exportSnapshot creates a snapshot and writes it to the snapshot location.
+func exportSnapshot(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) error {
+	result, err := createSnapshot(client, ctx, config)
+	if err != nil {
+		return err
+	}
+
+	err = writeSnapshot(client, ctx, result, config.SnapshotLocation)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("exported snapshot of downscaled workloads", "amount", len(result.Workloads), "location", config.SnapshotLocation)
+
+	return nil
+}
+
+// restoreSnapshot restores the original state of all workloads in the snapshot and upscales them.
+// Annotations still present on a workload take precedence over the snapshot.
+func restoreSnapshot(client kubernetes.Client, ctx context.Context, result *snapshot) error {
+	entriesByResource := make(map[string][]snapshotEntry)
+	for _, entry := range result.Workloads {
+		entriesByResource[entry.Resource] = append(entriesByResource[entry.Resource], entry)
+	}
+
+	failed := 0
+
+	for resource, entries := range entriesByResource {
+		namespaces := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			namespaces = append(namespaces, entry.Namespace)
+		}
+
+		slices.Sort(namespaces)
+		namespaces = slices.Compact(namespaces)
+
+		workloads, err := client.GetWorkloads(namespaces, []string{resource}, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get workloads: %w", err)
+		}
+
+		workloadsByName := make(map[string]scalable.Workload, len(workloads))
+		for _, workload := range workloads {
+			workloadsByName[workload.GetNamespace()+"/"+workload.GetName()] = workload
+		}
+
+		for _, entry := range entries {
+			workload, ok := workloadsByName[entry.Namespace+"/"+entry.Name]
+			if !ok {
+				slog.Warn("workload of snapshot doesn't exist anymore, skipping", "workload", entry.Name, "namespace", entry.Namespace)
+				continue
+			}
+
+			if scalable.RestoreOriginalState(workload, entry.Annotations) {
+				slog.Info("restored original state from snapshot", "workload", entry.Name, "namespace", entry.Namespace)
+			}
+
+			err = client.UpscaleWorkload(workload, "restored from a snapshot", ctx)
+			if err != nil {
+				slog.Error("failed to upscale workload", "error", err, "workload", entry.Name, "namespace", entry.Namespace)
+
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return newSnapshotRestoreError(failed, len(result.Workloads))
+	}
+
+	slog.Info("restored snapshot", "amount", len(result.Workloads), "createdAt", result.CreatedAt)
+
+	return nil
+}
+
+// importSnapshot reads the snapshot from the snapshot location and restores it.
+func importSnapshot(client kubernetes.Client, ctx context.Context, config *runtimeConfiguration) error {
+	result, err := readSnapshot(client, ctx, config.SnapshotLocation)
+	if err != nil {
+		return err
+	}
+
+	return restoreSnapshot(client, ctx, result)
+}
+
+// parseConfigMapLocation parses a snapshot location in the format "configmap:[namespace/]name".
+// It returns false if the location is a file path.
+//
+//nolint:nonamedreturns // using named return values for clarity
+func parseConfigMapLocation(location string) (namespace, name string, isConfigMap bool) {
+	reference, isConfigMap := strings.CutPrefix(location, configMapLocationPrefix)
+	if !isConfigMap {
+		return "", "", false
+	}
+
+	namespace, name, found := strings.Cut(reference, "/")
+	if !found {
+		return "", reference, true
+	}
+
+	return namespace, name, true
+}
+
+// writeSnapshot writes the snapshot to either a configmap or a file.
+func writeSnapshot(client kubernetes.Client, ctx context.Context, result *snapshot, location string) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	if namespace, name, isConfigMap := parseConfigMapLocation(location); isConfigMap {
+		err = client.SaveConfigMapData(namespace, name, map[string]string{snapshotConfigMapKey: string(data)}, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+
+		return nil
+	}
+
+	err = os.WriteFile(location, data, snapshotFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+// readSnapshot reads the snapshot from either a configmap or a file.
+func readSnapshot(client kubernetes.Client, ctx context.Context, location string) (*snapshot, error) {
+	var data []byte
+
+	if namespace, name, isConfigMap := parseConfigMapLocation(location); isConfigMap {
+		configMapData, err := client.GetConfigMapData(namespace, name, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot: %w", err)
+		}
+
+		data = []byte(configMapData[snapshotConfigMapKey])
+	} else {
+		fileData, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+		}
+
+		data = fileData
+	}
+
+	var result snapshot
+
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	return &result, nil
+}
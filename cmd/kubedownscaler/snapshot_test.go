@@ -0,0 +1,110 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigMapLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		location        string
+		wantNamespace   string
+		wantName        string
+		wantIsConfigMap bool
+	}{
+		{
+			name:            "file",
+			location:        "/tmp/snapshot.json",
+			wantIsConfigMap: false,
+		},
+		{
+			name:            "configmap in downscaler namespace",
+			location:        "configmap:downscaler-snapshot",
+			wantName:        "downscaler-snapshot",
+			wantIsConfigMap: true,
+		},
+		{
+			name:            "configmap in other namespace",
+			location:        "configmap:backup/downscaler-snapshot",
+			wantNamespace:   "backup",
+			wantName:        "downscaler-snapshot",
+			wantIsConfigMap: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			namespace, name, isConfigMap := parseConfigMapLocation(test.location)
+			assert.Equal(t, test.wantNamespace, namespace)
+			assert.Equal(t, test.wantName, name)
+			assert.Equal(t, test.wantIsConfigMap, isConfigMap)
+		})
+	}
+}
+
+func newDeploymentFromJSON(t *testing.T, raw string) scalable.Workload {
+	t.Helper()
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(raw))
+	require.NoError(t, err)
+
+	return workload
+}
+
+func TestSnapshotExportAndRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	config := getDefaultConfig()
+	config.SnapshotLocation = filepath.Join(t.TempDir(), "snapshot.json")
+
+	downscaled := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "downscaled", "namespace": "default", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 0}
+	}`)
+	upscaled := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "upscaled", "namespace": "default"},
+		"spec": {"replicas": 2}
+	}`)
+
+	exportClient := new(MockClient)
+	exportClient.On("GetWorkloads", config.IncludeNamespaces, []string{"deployments"}, ctx).
+		Return([]scalable.Workload{downscaled, upscaled}, nil)
+
+	require.NoError(t, exportSnapshot(exportClient, ctx, config))
+
+	result, err := readSnapshot(nil, ctx, config.SnapshotLocation)
+	require.NoError(t, err)
+	assert.Equal(t, []snapshotEntry{{
+		Resource:    "deployments",
+		Namespace:   "default",
+		Name:        "downscaled",
+		Annotations: map[string]string{"downscaler/original-replicas": "3"},
+	}}, result.Workloads)
+
+	// the annotations were wiped by a bad apply
+	wiped := newDeploymentFromJSON(t, `{
+		"metadata": {"name": "downscaled", "namespace": "default"},
+		"spec": {"replicas": 0}
+	}`)
+
+	restoreClient := new(MockClient)
+	restoreClient.On("GetWorkloads", []string{"default"}, []string{"deployments"}, ctx).
+		Return([]scalable.Workload{wiped}, nil)
+	restoreClient.On("UpscaleWorkload", mock.Anything, mock.Anything, ctx).Return(nil)
+
+	require.NoError(t, restoreSnapshot(restoreClient, ctx, result))
+
+	restoreClient.AssertCalled(t, "UpscaleWorkload", wiped, "restored from a snapshot", ctx)
+	assert.Equal(t, map[string]string{"downscaler/original-replicas": "3"}, wiped.GetAnnotations())
+}
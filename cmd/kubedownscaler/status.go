@@ -0,0 +1,455 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	decisionExcluded    = "excluded"
+	decisionGracePeriod = "grace-period"
+	decisionFailed      = "failed"
+
+	// nextTransitionLimit is how far into the future the next transition of a workload is searched.
+	nextTransitionLimit = 8 * 24 * time.Hour
+)
+
+// workloadStatus is the state of a single workload in the last scan cycle.
+type workloadStatus struct {
+	Kind             string     `json:"kind"`
+	Namespace        string     `json:"namespace"`
+	Name             string     `json:"name"`
+	Decision         string     `json:"decision"`
+	DecidingScope    string     `json:"decidingScope,omitempty"`
+	OriginalReplicas string     `json:"originalReplicas,omitempty"`
+	NextTransition   *time.Time `json:"nextTransition,omitempty"`
+	NextScaling      string     `json:"nextScaling,omitempty"`
+	LastError        string     `json:"lastError,omitempty"`
+}
+
+// cycleSummary summarizes a finished scan cycle.
+type cycleSummary struct {
+	ID         string    `json:"id"`
+	StartTime  time.Time `json:"startTime"`
+	Duration   string    `json:"duration"`
+	GlobalMode string    `json:"globalMode"`
+	Workloads  int       `json:"workloads"`
+	Downscaled int       `json:"downscaled"`
+	Upscaled   int       `json:"upscaled"`
+	Excluded   int       `json:"excluded"`
+	Failed     int       `json:"failed"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// statusResponse is the response of the status api.
+type statusResponse struct {
+	LastCycle *cycleSummary     `json:"lastCycle"`
+	Workloads []*workloadStatus `json:"workloads"`
+}
+
+// scanStatus keeps track of the workloads of the running scan cycle, the results of the last finished scan cycle
+// and the health of the scanning. It is safe for concurrent use.
+type scanStatus struct {
+	mutex      sync.RWMutex
+	cycleID    string
+	cycleStart time.Time
+	globalMode values.GlobalMode
+	pending    map[string]*workloadStatus
+	workloads  map[string]*workloadStatus
+	previous   map[string]*workloadStatus
+	lastCycle  *cycleSummary
+	health     scanHealth
+}
+
+// scanHealth describes how healthy the scanning of the downscaler is.
+type scanHealth struct {
+	leading             bool
+	leadingSince        time.Time
+	lastSuccessfulCycle time.Time
+	consecutiveFailures int
+	lastFailure         string
+}
+
+// newScanStatus creates a new empty scan status.
+func newScanStatus() *scanStatus {
+	return &scanStatus{
+		pending:   make(map[string]*workloadStatus),
+		workloads: make(map[string]*workloadStatus),
+		previous:  make(map[string]*workloadStatus),
+	}
+}
+
+// getStatusKey gets the key of the workload in the scan status.
+func getStatusKey(workload scalable.Workload) string {
+	return workload.GroupVersionKind().Kind + "/" + workload.GetNamespace() + "/" + workload.GetName()
+}
+
+// getStatusKeyOf gets the key of the workload status in the scan status.
+func getStatusKeyOf(status *workloadStatus) string {
+	return status.Kind + "/" + status.Namespace + "/" + status.Name
+}
+
+// startCycle starts recording a new scan cycle.
+func (s *scanStatus) startCycle(cycleID string, globalMode values.GlobalMode) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cycleID = cycleID
+	s.cycleStart = time.Now()
+	s.globalMode = globalMode
+	s.pending = make(map[string]*workloadStatus)
+}
+
+// getOrCreatePending gets the status of the workload in the running scan cycle. The caller has to hold the lock.
+func (s *scanStatus) getOrCreatePending(workload scalable.Workload) *workloadStatus {
+	key := getStatusKey(workload)
+
+	status, ok := s.pending[key]
+	if !ok {
+		status = &workloadStatus{
+			Kind:      workload.GroupVersionKind().Kind,
+			Namespace: workload.GetNamespace(),
+			Name:      workload.GetName(),
+		}
+		s.pending[key] = status
+	}
+
+	if originalReplicas, ok := scalable.GetOriginalReplicas(workload); ok {
+		status.OriginalReplicas = originalReplicas
+	}
+
+	return status
+}
+
+// recordExcluded records that the workload was excluded from scaling in the running scan cycle.
+func (s *scanStatus) recordExcluded(workload scalable.Workload) {
+	s.recordSkipped(workload, decisionExcluded)
+}
+
+// recordExcludedWorkloads records all workloads which were filtered out of the included workloads as excluded.
+func (s *scanStatus) recordExcludedWorkloads(workloads, included []scalable.Workload) {
+	if s == nil {
+		return
+	}
+
+	includedUIDs := make(map[types.UID]struct{}, len(included))
+	for _, workload := range included {
+		includedUIDs[workload.GetUID()] = struct{}{}
+	}
+
+	for _, workload := range workloads {
+		if _, ok := includedUIDs[workload.GetUID()]; !ok {
+			s.recordExcluded(workload)
+		}
+	}
+}
+
+// recordSkipped records that the workload wasn't scaled for the reason in the running scan cycle.
+func (s *scanStatus) recordSkipped(workload scalable.Workload, reason string) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.getOrCreatePending(workload).Decision = reason
+}
+
+// getPreviousDecision gets the decision of the workload in the last finished scan cycle.
+// It returns false if the workload wasn't part of the last finished scan cycle.
+func (s *scanStatus) getPreviousDecision(workload scalable.Workload) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	status, ok := s.workloads[getStatusKey(workload)]
+	if !ok {
+		return "", false
+	}
+
+	return status.Decision, true
+}
+
+// recordDecision records the scaling decided for the workload in the running scan cycle.
+func (s *scanStatus) recordDecision(decision *scalingDecision) {
+	if s == nil {
+		return
+	}
+
+	status := &workloadStatus{Decision: decision.scaling.String()}
+
+	if scopeID, ok := decision.scopes.GetDecidingScope(); ok {
+		status.DecidingScope = scopeID.String()
+	}
+
+	nextTransition, nextScaling, ok := decision.scopes.GetNextScalingChange(time.Now(), nextTransitionLimit)
+	if ok {
+		status.NextTransition = &nextTransition
+		status.NextScaling = nextScaling.String()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending := s.getOrCreatePending(decision.workload)
+	pending.Decision = status.Decision
+	pending.DecidingScope = status.DecidingScope
+	pending.NextTransition = status.NextTransition
+	pending.NextScaling = status.NextScaling
+}
+
+// recordError records that evaluating or scaling the workload failed in the running scan cycle.
+func (s *scanStatus) recordError(workload scalable.Workload, err error) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status := s.getOrCreatePending(workload)
+	if status.Decision == "" {
+		status.Decision = decisionFailed
+	}
+
+	status.LastError = err.Error()
+}
+
+// finishCycle makes the running scan cycle the last finished scan cycle.
+// If the cycle was aborted by the safety limits, the workloads of the previous cycle are kept, since nothing was scaled,
+// and no changes are reported for the cycle.
+func (s *scanStatus) finishCycle(err error) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	summary := &cycleSummary{
+		ID:         s.cycleID,
+		StartTime:  s.cycleStart,
+		Duration:   time.Since(s.cycleStart).String(),
+		GlobalMode: string(s.globalMode),
+		Workloads:  len(s.pending),
+	}
+
+	for _, status := range s.pending {
+		switch {
+		case status.LastError != "":
+			summary.Failed++
+		case status.Decision == values.ScalingDown.String():
+			summary.Downscaled++
+		case status.Decision == values.ScalingUp.String():
+			summary.Upscaled++
+		case status.Decision == decisionExcluded, status.Decision == decisionGracePeriod:
+			summary.Excluded++
+		}
+	}
+
+	if err != nil {
+		summary.Error = err.Error()
+	}
+
+	var safetyLimitExceededErr *SafetyLimitExceededError
+
+	s.previous = s.workloads
+	if !errors.As(err, &safetyLimitExceededErr) {
+		s.workloads = s.pending
+	}
+
+	s.pending = make(map[string]*workloadStatus)
+	s.lastCycle = summary
+
+	s.recordCycleHealth(err, summary)
+}
+
+// recordCycleHealth updates the health with the result of a finished scan cycle. The caller has to hold the lock.
+// A cycle failed if it returned an error or all of its workloads failed. Cycles aborted by the safety limits didn't fail,
+// since they are aborted on purpose.
+func (s *scanStatus) recordCycleHealth(err error, summary *cycleSummary) {
+	var safetyLimitExceededErr *SafetyLimitExceededError
+
+	switch {
+	case err != nil && !errors.As(err, &safetyLimitExceededErr):
+		s.health.consecutiveFailures++
+		s.health.lastFailure = err.Error()
+	case summary.Workloads > 0 && summary.Failed == summary.Workloads:
+		s.health.consecutiveFailures++
+		s.health.lastFailure = "all workloads of the cycle failed"
+	default:
+		s.health.consecutiveFailures = 0
+		s.health.lastFailure = ""
+		s.health.lastSuccessfulCycle = time.Now()
+	}
+}
+
+// recordPausedCycle records a cycle which was skipped, because the downscaler is paused by the global mode.
+// Paused cycles count as successful, since the downscaler isn't stuck.
+func (s *scanStatus) recordPausedCycle() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.health.consecutiveFailures = 0
+	s.health.lastFailure = ""
+	s.health.lastSuccessfulCycle = time.Now()
+}
+
+// setLeading sets if this instance is currently scanning workloads.
+func (s *scanStatus) setLeading(leading bool) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.health.leading = leading
+	s.health.leadingSince = time.Now()
+}
+
+// getHealth gets a copy of the current health of the scanning.
+func (s *scanStatus) getHealth() scanHealth {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.health
+}
+
+// getStatus gets the last finished scan cycle and its workloads, sorted by namespace, kind and name.
+// If namespaces is not empty, only workloads in these namespaces are returned.
+func (s *scanStatus) getStatus(namespaces []string) *statusResponse {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	response := &statusResponse{
+		LastCycle: s.lastCycle,
+		Workloads: make([]*workloadStatus, 0, len(s.workloads)),
+	}
+
+	for _, status := range s.workloads {
+		if len(namespaces) > 0 && !slices.Contains(namespaces, status.Namespace) {
+			continue
+		}
+
+		response.Workloads = append(response.Workloads, status)
+	}
+
+	slices.SortFunc(response.Workloads, func(a, b *workloadStatus) int {
+		return strings.Compare(a.Namespace+"/"+a.Kind+"/"+a.Name, b.Namespace+"/"+b.Kind+"/"+b.Name)
+	})
+
+	return response
+}
+
+// getNotificationEvents gets the changes of the namespaces in the last finished scan cycle.
+func (s *scanStatus) getNotificationEvents() []notification.Event {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return getNotificationEvents(s.previous, s.workloads)
+}
+
+// getWorkloadSamples gets the state of the workloads of the last finished scan cycle for the workload metrics.
+// Workloads without a scaling decision, e.g. because they aren't managed by any scope, are left out.
+func (s *scanStatus) getWorkloadSamples() []metrics.WorkloadSample {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	samples := make([]metrics.WorkloadSample, 0, len(s.workloads))
+
+	for _, status := range s.workloads {
+		var state string
+
+		switch {
+		case status.LastError != "":
+			state = metrics.WorkloadStateFailed
+		case status.Decision == values.ScalingDown.String():
+			state = metrics.WorkloadStateDownscaled
+		case status.Decision == values.ScalingUp.String():
+			state = metrics.WorkloadStateUpscaled
+		case status.Decision == decisionExcluded, status.Decision == decisionGracePeriod:
+			state = metrics.WorkloadStateExcluded
+		default:
+			continue
+		}
+
+		samples = append(samples, metrics.WorkloadSample{
+			Kind:           status.Kind,
+			Namespace:      status.Namespace,
+			Name:           status.Name,
+			State:          state,
+			NextTransition: status.NextTransition,
+		})
+	}
+
+	return samples
+}
+
+// getNamespacesFromQuery gets the namespaces to filter by from the namespace query parameters.
+// Multiple namespaces can be given by repeating the parameter or separating them with commas.
+func getNamespacesFromQuery(req *http.Request) []string {
+	var namespaces []string
+
+	for _, value := range req.URL.Query()["namespace"] {
+		for namespace := range strings.SplitSeq(value, ",") {
+			namespace = strings.TrimSpace(namespace)
+			if namespace != "" {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	return namespaces
+}
+
+// ServeHTTP serves the last finished scan cycle as json.
+func (s *scanStatus) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response := s.getStatus(getNamespacesFromQuery(req))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.Error("failed to write status response", "error", err)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanStatus(t *testing.T) {
+	t.Parallel()
+
+	downscaled := newDeploymentFromJSON(t, `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"name": "downscaled", "namespace": "team-a", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 0}
+	}`)
+	excluded := newDeploymentFromJSON(t, `{
+		"kind": "Deployment",
+		"metadata": {"name": "excluded", "namespace": "team-a"}
+	}`)
+	failed := newDeploymentFromJSON(t, `{
+		"kind": "Deployment",
+		"metadata": {"name": "failed", "namespace": "team-b"}
+	}`)
+
+	scopeWorkload := values.NewScope()
+	require.NoError(t, scopeWorkload.DownTime.Set("always"))
+
+	status := newScanStatus()
+	status.startCycle("cycle", values.GlobalModeResume)
+	status.recordDecision(&scalingDecision{
+		workload: downscaled,
+		scopes:   values.Scopes{scopeWorkload, values.NewScope(), values.NewScope(), values.NewScope(), values.GetDefaultScope()},
+		scaling:  values.ScalingDown,
+	})
+	status.recordExcluded(excluded)
+	status.recordError(failed, errors.New("conflict"))
+
+	assert.Empty(t, status.getStatus(nil).Workloads, "the running cycle shouldn't be visible")
+
+	status.finishCycle(nil)
+
+	result := status.getStatus(nil)
+	require.Len(t, result.Workloads, 3)
+	assert.Equal(t, "cycle", result.LastCycle.ID)
+	assert.Equal(t, 3, result.LastCycle.Workloads)
+	assert.Equal(t, 1, result.LastCycle.Downscaled)
+	assert.Equal(t, 1, result.LastCycle.Excluded)
+	assert.Equal(t, 1, result.LastCycle.Failed)
+
+	assert.Equal(t, &workloadStatus{
+		Kind:             "Deployment",
+		Namespace:        "team-a",
+		Name:             "downscaled",
+		Decision:         "down",
+		DecidingScope:    values.ScopeWorkload.String(),
+		OriginalReplicas: "3",
+	}, result.Workloads[0])
+	assert.Equal(t, "conflict", result.Workloads[2].LastError)
+
+	filtered := status.getStatus([]string{"team-b"})
+	require.Len(t, filtered.Workloads, 1)
+	assert.Equal(t, "failed", filtered.Workloads[0].Name)
+}
+
+func TestScanStatusRecordExcludedWorkloads(t *testing.T) {
+	t.Parallel()
+
+	included := newDeploymentFromJSON(t, `{"kind": "Deployment", "metadata": {"name": "included", "namespace": "team-a", "uid": "1"}}`)
+	excluded := newDeploymentFromJSON(t, `{"kind": "Deployment", "metadata": {"name": "excluded", "namespace": "team-a", "uid": "2"}}`)
+
+	status := newScanStatus()
+	status.startCycle("cycle", values.GlobalModeResume)
+	status.recordExcludedWorkloads([]scalable.Workload{included, excluded}, []scalable.Workload{included})
+	status.finishCycle(nil)
+
+	result := status.getStatus(nil)
+	require.Len(t, result.Workloads, 1)
+	assert.Equal(t, "excluded", result.Workloads[0].Name)
+	assert.Equal(t, decisionExcluded, result.Workloads[0].Decision)
+	assert.Equal(t, 1, result.LastCycle.Excluded)
+}
+
+func TestScanStatusServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	status := newScanStatus()
+	status.startCycle("cycle", values.GlobalModeResume)
+	status.recordExcluded(newDeploymentFromJSON(t, `{"metadata": {"name": "a", "namespace": "team-a"}}`))
+	status.recordExcluded(newDeploymentFromJSON(t, `{"metadata": {"name": "b", "namespace": "team-b"}}`))
+	status.recordExcluded(newDeploymentFromJSON(t, `{"metadata": {"name": "c", "namespace": "team-c"}}`))
+	status.finishCycle(nil)
+
+	recorder := httptest.NewRecorder()
+	status.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/status?namespace=team-a,team-c", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var response statusResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Workloads, 2)
+	assert.Equal(t, "team-a", response.Workloads[0].Namespace)
+	assert.Equal(t, "team-c", response.Workloads[1].Namespace)
+
+	recorder = httptest.NewRecorder()
+	status.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/status", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
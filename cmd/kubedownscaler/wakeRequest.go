@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+// resolveWakeRequest converts a new wake request of the workload into the absolute time it expires at
+// or removes an expired wake request.
+func resolveWakeRequest(workload scalable.Workload, scopeWorkload *values.Scope, client kubernetes.Client, ctx context.Context) error {
+	wakeUntil, ok := scopeWorkload.GetWakeUpdate(time.Now())
+	if !ok {
+		return nil
+	}
+
+	slog.Info(
+		"updating wake request of workload",
+		"workload", workload.GetName(),
+		"namespace", workload.GetNamespace(),
+		"wakeUntil", wakeUntil,
+	)
+
+	err := client.UpdateWakeRequest(workload, wakeUntil, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update wake request of workload: %w", err)
+	}
+
+	return nil
+}
+
+// resolveNamespaceWakeRequests converts new wake requests of the namespaces into the absolute time they expire at
+// and removes expired wake requests. Failures are only logged, since the wake requests are still honored until they are resolved.
+func resolveNamespaceWakeRequests(namespaceScopes map[string]*values.Scope, client kubernetes.Client, ctx context.Context) {
+	now := time.Now()
+
+	for namespace, scope := range namespaceScopes {
+		wakeUntil, ok := scope.GetWakeUpdate(now)
+		if !ok || namespace == "" {
+			continue
+		}
+
+		slog.Info("updating wake request of namespace", "namespace", namespace, "wakeUntil", wakeUntil)
+
+		err := client.UpdateNamespaceWakeRequest(namespace, wakeUntil, ctx)
+		if err != nil {
+			slog.Error("failed to update wake request of namespace", "error", err, "namespace", namespace)
+		}
+	}
+}
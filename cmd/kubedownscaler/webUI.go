@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+const (
+	// reviewTimeout is how long the web ui waits for the token and access reviews of the api server.
+	reviewTimeout = 5 * time.Second
+
+	namespaceStateDownscaled = "downscaled"
+	namespaceStateUpscaled   = "upscaled"
+	namespaceStatePartial    = "partially downscaled"
+)
+
+//go:embed webui
+var webUIFiles embed.FS
+
+// namespaceOverview is the state of a namespace and its workloads in the last scan cycle.
+type namespaceOverview struct {
+	Name           string            `json:"name"`
+	State          string            `json:"state,omitempty"`
+	NextTransition *time.Time        `json:"nextTransition,omitempty"`
+	NextScaling    string            `json:"nextScaling,omitempty"`
+	Workloads      []*workloadStatus `json:"workloads"`
+}
+
+// overviewResponse is the response of the namespaces api of the web ui.
+type overviewResponse struct {
+	User        string               `json:"user"`
+	LastCycle   *cycleSummary        `json:"lastCycle"`
+	MaxDuration string               `json:"maxDuration"`
+	Namespaces  []*namespaceOverview `json:"namespaces"`
+}
+
+// overrideRequest is the body of a request to wake up or exclude a namespace.
+type overrideRequest struct {
+	Duration string `json:"duration"`
+}
+
+// overrideResponse is the response to a request to wake up or exclude a namespace.
+type overrideResponse struct {
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// webUI serves the web ui, which shows the namespaces of the last scan cycle and lets authorized users
+// wake up or exclude a namespace temporarily. Users authenticate with their Kubernetes token and may only
+// override namespaces they are allowed to patch.
+type webUI struct {
+	status      *scanStatus
+	client      kubernetes.Client
+	maxDuration time.Duration
+	now         func() time.Time
+}
+
+// newWebUIHandler creates the handler serving the web ui and its api.
+func newWebUIHandler(status *scanStatus, client kubernetes.Client, maxDuration time.Duration) http.Handler {
+	ui := &webUI{status: status, client: client, maxDuration: maxDuration, now: time.Now}
+
+	files, err := fs.Sub(webUIFiles, "webui")
+	if err != nil {
+		panic(fmt.Sprintf("embedded web ui files are missing: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(files))
+	mux.HandleFunc("GET /api/v1/namespaces", ui.handleOverview)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/wake", ui.handleWake)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/exclude", ui.handleExclude)
+
+	return withSecurityHeaders(mux)
+}
+
+// withSecurityHeaders only allows the web ui to load its own files and prevents it from being framed.
+func withSecurityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// handleOverview serves the namespaces of the last scan cycle, which the authenticated user may get.
+func (u *webUI) handleOverview(w http.ResponseWriter, req *http.Request) {
+	user, ok := u.authenticate(w, req)
+	if !ok {
+		return
+	}
+
+	status := u.status.getStatus(nil)
+
+	ctx, cancel := context.WithTimeout(req.Context(), reviewTimeout)
+	defer cancel()
+
+	namespaces, err := u.filterVisibleNamespaces(user, getNamespaceOverviews(status.Workloads), ctx)
+	if err != nil {
+		slog.Error("failed to review access of web ui user", "error", err, "user", user.Username)
+		http.Error(w, "failed to review access", http.StatusInternalServerError)
+
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, &overviewResponse{
+		User:        user.Username,
+		LastCycle:   status.LastCycle,
+		MaxDuration: u.maxDuration.String(),
+		Namespaces:  namespaces,
+	})
+}
+
+// filterVisibleNamespaces filters the namespaces to the ones the user may get.
+// Users who may get all namespaces are only reviewed once.
+func (u *webUI) filterVisibleNamespaces(
+	user authenticationv1.UserInfo,
+	namespaces []*namespaceOverview,
+	ctx context.Context,
+) ([]*namespaceOverview, error) {
+	allowed, err := u.client.CanGetNamespace(user, "", ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review access to all namespaces: %w", err)
+	}
+
+	if allowed {
+		return namespaces, nil
+	}
+
+	visible := make([]*namespaceOverview, 0, len(namespaces))
+
+	for _, namespace := range namespaces {
+		allowed, err = u.client.CanGetNamespace(user, namespace.Name, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to review access to namespace %q: %w", namespace.Name, err)
+		}
+
+		if allowed {
+			visible = append(visible, namespace)
+		}
+	}
+
+	return visible, nil
+}
+
+// handleWake wakes up the namespace for the requested duration using the wake request annotation.
+func (u *webUI) handleWake(w http.ResponseWriter, req *http.Request) {
+	u.handleOverride(w, req, "wake up", values.GetWakeRequestAnnotations)
+}
+
+// handleExclude excludes the namespace for the requested duration using the exclude until annotation.
+func (u *webUI) handleExclude(w http.ResponseWriter, req *http.Request) {
+	u.handleOverride(w, req, "exclusion", func(duration time.Duration) map[string]string {
+		return values.GetExclusionAnnotations(u.now().Add(duration))
+	})
+}
+
+// handleOverride annotates the namespace with the annotations for the requested duration, if the user may patch the namespace.
+func (u *webUI) handleOverride(
+	w http.ResponseWriter,
+	req *http.Request,
+	override string,
+	getAnnotations func(duration time.Duration) map[string]string,
+) {
+	user, ok := u.authenticate(w, req)
+	if !ok {
+		return
+	}
+
+	namespace := req.PathValue("namespace")
+
+	duration, err := u.parseOverrideRequest(w, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), reviewTimeout)
+	defer cancel()
+
+	allowed, err := u.client.CanPatchNamespace(user, namespace, ctx)
+	if err != nil {
+		slog.Error("failed to review access of web ui user", "error", err, "user", user.Username, "namespace", namespace)
+		http.Error(w, "failed to review access", http.StatusInternalServerError)
+
+		return
+	}
+
+	if !allowed {
+		http.Error(w, fmt.Sprintf("user %q may not patch namespace %q", user.Username, namespace), http.StatusForbidden)
+		return
+	}
+
+	annotations := getAnnotations(duration)
+
+	err = u.client.AnnotateNamespace(namespace, annotations, ctx)
+	if err != nil {
+		slog.Error("failed to annotate namespace", "error", err, "user", user.Username, "namespace", namespace)
+		http.Error(w, "failed to annotate namespace", http.StatusInternalServerError)
+
+		return
+	}
+
+	slog.Info("namespace overridden via web ui", "override", override, "user", user.Username, "namespace", namespace, "duration", duration)
+
+	writeJSONResponse(w, http.StatusOK, &overrideResponse{Namespace: namespace, Annotations: annotations})
+}
+
+// parseOverrideRequest parses the requested duration, which has to be positive and may not exceed the maximum duration.
+func (u *webUI) parseOverrideRequest(w http.ResponseWriter, req *http.Request) (time.Duration, error) {
+	var body overrideRequest
+
+	err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<10)).Decode(&body) //nolint:mnd // the body only holds a duration
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse request: %w", err)
+	}
+
+	var duration util.DurationValue
+
+	err = duration.Set(body.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %w", err)
+	}
+
+	if duration <= 0 || time.Duration(duration) > u.maxDuration {
+		return 0, newInvalidOverrideDurationError(body.Duration, u.maxDuration)
+	}
+
+	return time.Duration(duration), nil
+}
+
+// authenticate authenticates the bearer token of the request. It writes an error and returns false if the user isn't authenticated.
+func (u *webUI) authenticate(w http.ResponseWriter, req *http.Request) (authenticationv1.UserInfo, bool) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+
+		return authenticationv1.UserInfo{}, false
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), reviewTimeout)
+	defer cancel()
+
+	user, authenticated, err := u.client.ReviewToken(strings.TrimSpace(token), ctx)
+	if err != nil {
+		slog.Error("failed to review token of web ui user", "error", err)
+		http.Error(w, "failed to review token", http.StatusInternalServerError)
+
+		return authenticationv1.UserInfo{}, false
+	}
+
+	if !authenticated {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "the token is invalid", http.StatusUnauthorized)
+
+		return authenticationv1.UserInfo{}, false
+	}
+
+	return user, true
+}
+
+// getNamespaceOverviews groups the workloads by namespace, sorted by the name of the namespace.
+// The next transition of a namespace is the earliest next transition of its workloads.
+func getNamespaceOverviews(workloads []*workloadStatus) []*namespaceOverview {
+	namespaces := make([]*namespaceOverview, 0)
+
+	for namespace, statuses := range groupByNamespace(newStatusMapOf(workloads)) {
+		overview := &namespaceOverview{Name: namespace, State: getNamespaceOverviewState(statuses), Workloads: statuses}
+
+		for _, status := range statuses {
+			if status.NextTransition == nil {
+				continue
+			}
+
+			if overview.NextTransition == nil || status.NextTransition.Before(*overview.NextTransition) {
+				overview.NextTransition = status.NextTransition
+				overview.NextScaling = status.NextScaling
+			}
+		}
+
+		namespaces = append(namespaces, overview)
+	}
+
+	slices.SortFunc(namespaces, func(a, b *namespaceOverview) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return namespaces
+}
+
+// newStatusMapOf gets the workloads keyed by their key in the scan status.
+func newStatusMapOf(workloads []*workloadStatus) map[string]*workloadStatus {
+	result := make(map[string]*workloadStatus, len(workloads))
+	for _, status := range workloads {
+		result[getStatusKeyOf(status)] = status
+	}
+
+	return result
+}
+
+// getNamespaceOverviewState gets if the scaled workloads of a namespace are downscaled, upscaled or both.
+// It returns an empty string if none of its workloads are scaled.
+func getNamespaceOverviewState(workloads []*workloadStatus) string {
+	var downscaled, upscaled bool
+
+	for _, status := range workloads {
+		switch status.Decision {
+		case values.ScalingDown.String():
+			downscaled = true
+		case values.ScalingUp.String():
+			upscaled = true
+		}
+	}
+
+	switch {
+	case downscaled && upscaled:
+		return namespaceStatePartial
+	case downscaled:
+		return namespaceStateDownscaled
+	case upscaled:
+		return namespaceStateUpscaled
+	default:
+		return ""
+	}
+}
+
+// writeJSONResponse writes the response as json with the status code.
+func writeJSONResponse(w http.ResponseWriter, statusCode int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.Error("failed to write web ui response", "error", err)
+	}
+}
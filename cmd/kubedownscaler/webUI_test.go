@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// webUIClient is a client accepting the token "valid" for "alice", who may get all namespaces but only patch the namespace "team-a",
+// and the token "restricted" for "bob", who may only get the namespace "team-b".
+type webUIClient struct {
+	kubernetes.Client
+
+	mutex       sync.Mutex
+	annotations map[string]map[string]string
+}
+
+func (w *webUIClient) ReviewToken(token string, _ context.Context) (authenticationv1.UserInfo, bool, error) {
+	switch token {
+	case "valid":
+		return authenticationv1.UserInfo{Username: "alice"}, true, nil
+	case "restricted":
+		return authenticationv1.UserInfo{Username: "bob"}, true, nil
+	default:
+		return authenticationv1.UserInfo{}, false, nil
+	}
+}
+
+func (w *webUIClient) CanGetNamespace(user authenticationv1.UserInfo, namespace string, _ context.Context) (bool, error) {
+	return user.Username == "alice" || (user.Username == "bob" && namespace == "team-b"), nil
+}
+
+func (w *webUIClient) CanPatchNamespace(user authenticationv1.UserInfo, namespace string, _ context.Context) (bool, error) {
+	return user.Username == "alice" && namespace == "team-a", nil
+}
+
+func (w *webUIClient) AnnotateNamespace(namespace string, annotations map[string]string, _ context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.annotations[namespace] = annotations
+
+	return nil
+}
+
+func newTestWebUI(t *testing.T) (*webUIClient, http.Handler) {
+	t.Helper()
+
+	client := &webUIClient{annotations: make(map[string]map[string]string)}
+
+	status := newScanStatus()
+	status.workloads = newStatusMap(
+		&workloadStatus{Kind: "Deployment", Namespace: "team-b", Name: "api", Decision: "up"},
+		&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "web", Decision: "down"},
+		&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "worker", Decision: "up"},
+	)
+
+	return client, newWebUIHandler(status, client, 12*time.Hour)
+}
+
+func serveTestRequest(handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestWebUIOverview(t *testing.T) {
+	t.Parallel()
+
+	_, handler := newTestWebUI(t)
+
+	response := serveTestRequest(handler, http.MethodGet, "/api/v1/namespaces", "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	response = serveTestRequest(handler, http.MethodGet, "/api/v1/namespaces", "expired", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	response = serveTestRequest(handler, http.MethodGet, "/api/v1/namespaces", "valid", "")
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var overview overviewResponse
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &overview))
+	assert.Equal(t, "alice", overview.User)
+	require.Len(t, overview.Namespaces, 2)
+	assert.Equal(t, "team-a", overview.Namespaces[0].Name)
+	assert.Equal(t, namespaceStatePartial, overview.Namespaces[0].State)
+	assert.Len(t, overview.Namespaces[0].Workloads, 2)
+	assert.Equal(t, namespaceStateUpscaled, overview.Namespaces[1].State)
+
+	response = serveTestRequest(handler, http.MethodGet, "/api/v1/namespaces", "restricted", "")
+	require.Equal(t, http.StatusOK, response.Code)
+
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &overview))
+	assert.Equal(t, "bob", overview.User)
+	require.Len(t, overview.Namespaces, 1, "users should only see the namespaces they may get")
+	assert.Equal(t, "team-b", overview.Namespaces[0].Name)
+}
+
+func TestWebUIOverviewWithoutAccess(t *testing.T) {
+	t.Parallel()
+
+	client := &webUIClient{annotations: make(map[string]map[string]string)}
+
+	status := newScanStatus()
+	status.workloads = newStatusMap(&workloadStatus{Kind: "Deployment", Namespace: "team-a", Name: "web", Decision: "down"})
+
+	handler := newWebUIHandler(status, client, 12*time.Hour)
+
+	response := serveTestRequest(handler, http.MethodGet, "/api/v1/namespaces", "restricted", "")
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var overview overviewResponse
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &overview))
+	assert.Equal(t, "bob", overview.User)
+	assert.NotNil(t, overview.Namespaces)
+	assert.Empty(t, overview.Namespaces, "users without access to any namespace should get an empty list")
+}
+
+func TestWebUIOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		path            string
+		token           string
+		body            string
+		wantCode        int
+		wantAnnotations []string
+	}{
+		{
+			name:            "wake up",
+			path:            "/api/v1/namespaces/team-a/wake",
+			token:           "valid",
+			body:            `{"duration":"2h"}`,
+			wantCode:        http.StatusOK,
+			wantAnnotations: []string{"downscaler/wake-for"},
+		},
+		{
+			name:            "exclude",
+			path:            "/api/v1/namespaces/team-a/exclude",
+			token:           "valid",
+			body:            `{"duration":"30m"}`,
+			wantCode:        http.StatusOK,
+			wantAnnotations: []string{"downscaler/exclude-until"},
+		},
+		{
+			name:     "not authenticated",
+			path:     "/api/v1/namespaces/team-a/wake",
+			body:     `{"duration":"2h"}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "not authorized",
+			path:     "/api/v1/namespaces/team-b/wake",
+			token:    "valid",
+			body:     `{"duration":"2h"}`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "duration too long",
+			path:     "/api/v1/namespaces/team-a/wake",
+			token:    "valid",
+			body:     `{"duration":"24h"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid duration",
+			path:     "/api/v1/namespaces/team-a/exclude",
+			token:    "valid",
+			body:     `{"duration":"forever"}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, handler := newTestWebUI(t)
+
+			response := serveTestRequest(handler, http.MethodPost, test.path, test.token, test.body)
+			assert.Equal(t, test.wantCode, response.Code)
+
+			annotations, annotated := client.annotations["team-a"]
+			assert.Equal(t, test.wantCode == http.StatusOK, annotated)
+
+			for _, annotation := range test.wantAnnotations {
+				assert.Contains(t, annotations, annotation)
+			}
+		})
+	}
+}
+
+func TestWebUIServesFiles(t *testing.T) {
+	t.Parallel()
+
+	_, handler := newTestWebUI(t)
+
+	response := serveTestRequest(handler, http.MethodGet, "/", "", "")
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "app.js")
+	assert.Contains(t, response.Header().Get("Content-Security-Policy"), "default-src 'self'")
+}
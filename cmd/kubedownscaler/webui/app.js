@@ -0,0 +1,155 @@
+"use strict";
+
+// the token is only kept for the browser tab and sent as bearer token to the api of the web ui
+const tokenKey = "kubedownscaler-token";
+
+function element(tag, text, className) {
+  const node = document.createElement(tag);
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  if (className !== undefined) {
+    node.className = className;
+  }
+  return node;
+}
+
+function showMessage(text, isError) {
+  const message = document.getElementById("message");
+  message.textContent = text;
+  message.className = isError ? "error" : "";
+}
+
+async function request(method, path, body) {
+  const response = await fetch(path, {
+    method: method,
+    headers: {
+      Authorization: "Bearer " + sessionStorage.getItem(tokenKey),
+      "Content-Type": "application/json",
+    },
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+
+  if (response.status === 401) {
+    sessionStorage.removeItem(tokenKey);
+    document.getElementById("login").hidden = false;
+    document.getElementById("overview").hidden = true;
+  }
+
+  if (!response.ok) {
+    throw new Error((await response.text()).trim());
+  }
+
+  return response.json();
+}
+
+async function override(namespace, action) {
+  const duration = document.getElementById("duration").value;
+
+  try {
+    await request("POST", "api/v1/namespaces/" + encodeURIComponent(namespace) + "/" + action, { duration: duration });
+    showMessage(
+      (action === "wake" ? "Woke up " : "Excluded ") +
+        namespace +
+        " for " +
+        duration +
+        ", it takes effect in the next scan cycle.",
+      false,
+    );
+  } catch (error) {
+    showMessage(error.message, true);
+  }
+}
+
+function renderNamespace(namespace) {
+  const details = element("details");
+  const summary = element("summary", namespace.name);
+  summary.appendChild(element("span", namespace.state || "not scaled", "state"));
+
+  if (namespace.nextTransition) {
+    summary.appendChild(
+      element("span", " — next " + namespace.nextScaling + " at " + new Date(namespace.nextTransition).toLocaleString()),
+    );
+  }
+
+  const wake = element("button", "Wake up");
+  wake.addEventListener("click", () => override(namespace.name, "wake"));
+  summary.appendChild(wake);
+
+  const exclude = element("button", "Exclude");
+  exclude.addEventListener("click", () => override(namespace.name, "exclude"));
+  summary.appendChild(exclude);
+
+  details.appendChild(summary);
+
+  const table = element("table");
+  const header = element("tr");
+  for (const title of ["Kind", "Name", "State", "Decided by", "Next transition", "Error"]) {
+    header.appendChild(element("th", title));
+  }
+  table.appendChild(header);
+
+  for (const workload of namespace.workloads) {
+    const row = element("tr");
+    row.appendChild(element("td", workload.kind));
+    row.appendChild(element("td", workload.name));
+    row.appendChild(element("td", workload.decision));
+    row.appendChild(element("td", workload.decidingScope || ""));
+    row.appendChild(
+      element(
+        "td",
+        workload.nextTransition
+          ? workload.nextScaling + " at " + new Date(workload.nextTransition).toLocaleString()
+          : "",
+      ),
+    );
+    row.appendChild(element("td", workload.lastError || "", "error"));
+    table.appendChild(row);
+  }
+
+  details.appendChild(table);
+
+  return details;
+}
+
+let namespaces = [];
+
+function render() {
+  const filter = document.getElementById("filter").value.trim();
+  const list = document.getElementById("namespaces");
+  list.replaceChildren(
+    ...namespaces.filter((namespace) => namespace.name.includes(filter)).map(renderNamespace),
+  );
+}
+
+async function load() {
+  try {
+    const overview = await request("GET", "api/v1/namespaces");
+
+    document.getElementById("login").hidden = true;
+    document.getElementById("overview").hidden = false;
+    document.getElementById("user").textContent = overview.user;
+    document.getElementById("duration").title = "at most " + overview.maxDuration;
+    document.getElementById("cycle").textContent = overview.lastCycle
+      ? "Last scan cycle: " + new Date(overview.lastCycle.startTime).toLocaleString()
+      : "No scan cycle finished yet.";
+
+    namespaces = overview.namespaces;
+    render();
+  } catch (error) {
+    showMessage(error.message, true);
+  }
+}
+
+document.getElementById("login").addEventListener("submit", (event) => {
+  event.preventDefault();
+  sessionStorage.setItem(tokenKey, document.getElementById("token").value.trim());
+  showMessage("", false);
+  load();
+});
+
+document.getElementById("filter").addEventListener("input", render);
+
+if (sessionStorage.getItem(tokenKey)) {
+  load();
+}
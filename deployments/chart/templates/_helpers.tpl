@@ -30,6 +30,33 @@ If replicaCount is greater than 1, leader election is enabled by default.
 {{- if gt (.Values.replicaCount | int) 1 -}}true{{- else -}}false{{- end -}}
 {{- end }}
 
+{{/*
+Audit log arguments, expects a dict with the values and the file name of the audit log
+*/}}
+{{- define "go-kube-downscaler.auditLogArgs" -}}
+{{- $auditLog := .Values.auditLog -}}
+{{- if eq $auditLog.destination "file" }}
+- --audit-log={{ $auditLog.directory }}/{{ .fileName }}
+- --audit-log-max-size={{ $auditLog.maxSize }}
+- --audit-log-max-backups={{ $auditLog.maxBackups }}
+{{- else if $auditLog.destination }}
+- --audit-log={{ $auditLog.destination }}
+{{- end }}
+{{- end }}
+
+{{/*
+Audit log volume, only used if the audit log is written to a file
+*/}}
+{{- define "go-kube-downscaler.auditLogVolume" -}}
+- name: audit-log
+  {{- if .Values.auditLog.existingClaim }}
+  persistentVolumeClaim:
+    claimName: {{ .Values.auditLog.existingClaim }}
+  {{- else }}
+  emptyDir: {}
+  {{- end }}
+{{- end }}
+
 {{/*
 Common labels
 */}}
@@ -145,6 +172,25 @@ Create defined permissions for the webhook clusterrole
     - get
     - patch
     - update
+{{- if .Values.webhookController.validatingWebhookConfiguration.enabled }}
+- apiGroups:
+    - admissionregistration.k8s.io
+  resources:
+    - validatingwebhookconfigurations
+  verbs:
+    - list
+    - watch
+- apiGroups:
+    - admissionregistration.k8s.io
+  resources:
+    - validatingwebhookconfigurations
+  resourceNames:
+    - annotations.kube-downscaler.k8s
+  verbs:
+    - get
+    - patch
+    - update
+{{- end }}
 {{- end }}
 {{- define "go-kube-downscaler.webhookController.clusterwide.permissions" -}}
 - apiGroups:
@@ -174,6 +220,10 @@ Create defined permissions for roles
     - namespaces
   verbs:
     - get
+    - update
+    {{- if .Values.webUI.enabled }}
+    - patch
+    {{- end }}
 - apiGroups:
     - ""
   resources:
@@ -343,6 +393,106 @@ Create defined permissions for roles
     - list
     - update
 {{- end }}
+{{- if eq $resource "verticalpodautoscalers" }}
+- apiGroups:
+    - autoscaling.k8s.io
+  resources:
+    - verticalpodautoscalers
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "nodepools" }}
+- apiGroups:
+    - karpenter.sh
+  resources:
+    - nodepools
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "rabbitmqclusters" }}
+- apiGroups:
+    - rabbitmq.com
+  resources:
+    - rabbitmqclusters
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "redisclusters" }}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  resources:
+    - redisclusters
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "redisreplications" }}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  resources:
+    - redisreplications
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "eventlisteners" }}
+- apiGroups:
+    - triggers.tekton.dev
+  resources:
+    - eventlisteners
+  verbs:
+    - get
+    - list
+    - update
+{{- end }}
+{{- if eq $resource "scheduledsparkapplications" }}
+- apiGroups:
+    - sparkoperator.k8s.io
+  resources:
+    - scheduledsparkapplications
+  verbs:
+    - get
+    - list
+    - update
+- apiGroups:
+    - sparkoperator.k8s.io
+  resources:
+    - sparkapplications
+  verbs:
+    - get
+    - list
+    - delete
+{{- end }}
+{{- end }}
+{{- if .Values.maintenanceService.name }}
+- apiGroups:
+    - ""
+  resources:
+    - services
+  verbs:
+    - list
+- apiGroups:
+    - networking.k8s.io
+  resources:
+    - ingresses
+  verbs:
+    - list
+    - update
+- apiGroups:
+    - gateway.networking.k8s.io
+  resources:
+    - httproutes
+  verbs:
+    - list
+    - update
 {{- end }}
 {{- end }}
 
@@ -527,9 +677,140 @@ Create webhook resources
   resources:
     - kafkabridges
 {{ end -}}
+{{ if eq $resource "verticalpodautoscalers" -}}
+- apiGroups:
+    - autoscaling.k8s.io
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - verticalpodautoscalers
+{{ end -}}
+{{ if eq $resource "nodepools" -}}
+- apiGroups:
+    - karpenter.sh
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - nodepools
+{{ end -}}
+{{ if eq $resource "rabbitmqclusters" -}}
+- apiGroups:
+    - rabbitmq.com
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - rabbitmqclusters
+{{ end -}}
+{{ if eq $resource "redisclusters" -}}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - redisclusters
+{{ end -}}
+{{ if eq $resource "redisreplications" -}}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - redisreplications
+{{ end -}}
+{{ if eq $resource "eventlisteners" -}}
+- apiGroups:
+    - triggers.tekton.dev
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - eventlisteners
+{{ end -}}
+{{ if eq $resource "scheduledsparkapplications" -}}
+- apiGroups:
+    - sparkoperator.k8s.io
+  apiVersions:
+    - "*"
+  operations:
+    - "CREATE"
+    - "UPDATE"
+  resources:
+    - scheduledsparkapplications
+{{ end -}}
 {{ end -}}
 {{- end }}
 
+{{/*
+scale subresources of the included resources handled by the webhook
+*/}}
+{{- define "go-kube-downscaler.webhookscaleresources" -}}
+{{- range $resource := .Values.includedResources -}}
+{{ if or (eq $resource "deployments") (eq $resource "statefulsets") -}}
+- apiGroups:
+    - apps
+  apiVersions:
+    - "*"
+  operations:
+    - "UPDATE"
+  resources:
+    - {{ $resource }}/scale
+{{ end -}}
+{{ if eq $resource "rollouts" -}}
+- apiGroups:
+    - argoproj.io
+  apiVersions:
+    - "*"
+  operations:
+    - "UPDATE"
+  resources:
+    - rollouts/scale
+{{ end -}}
+{{ end -}}
+{{- end }}
+
+{{/*
+Create permissions to get the workloads whose scale subresource is handled by the webhook and store their requested replicas
+*/}}
+{{- define "go-kube-downscaler.webhookController.scaleTargets.permissions" -}}
+{{- range $resource := .Values.includedResources }}
+{{- if or (eq $resource "deployments") (eq $resource "statefulsets") }}
+- apiGroups:
+    - apps
+  resources:
+    - {{ $resource }}
+  verbs:
+    - get
+    - patch
+{{- end }}
+{{- if eq $resource "rollouts" }}
+- apiGroups:
+    - argoproj.io
+  resources:
+    - rollouts
+  verbs:
+    - get
+    - patch
+{{- end }}
+{{- end }}
+{{- end }}
+
 
 {{/*
 resources include in annotationsCompliance
@@ -745,5 +1026,96 @@ resources include in annotationsCompliance
   resources:
     - kafkabridges
 {{ end -}}
+{{ if eq $resource "verticalpodautoscalers" -}}
+- apiGroups:
+    - autoscaling.k8s.io
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - verticalpodautoscalers
+{{ end -}}
+{{ if eq $resource "nodepools" -}}
+- apiGroups:
+    - karpenter.sh
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - nodepools
+{{ end -}}
+{{ if eq $resource "rabbitmqclusters" -}}
+- apiGroups:
+    - rabbitmq.com
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - rabbitmqclusters
+{{ end -}}
+{{ if eq $resource "redisclusters" -}}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - redisclusters
+{{ end -}}
+{{ if eq $resource "redisreplications" -}}
+- apiGroups:
+    - redis.redis.opstreelabs.in
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - redisreplications
+{{ end -}}
+{{ if eq $resource "eventlisteners" -}}
+- apiGroups:
+    - triggers.tekton.dev
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - eventlisteners
+{{ end -}}
+{{ if eq $resource "scheduledsparkapplications" -}}
+- apiGroups:
+    - sparkoperator.k8s.io
+  apiVersions:
+    - "*"
+  operations:
+  {{- if $createUpdate }}
+    - "CREATE"
+  {{- end }}
+    - "UPDATE"
+  resources:
+    - scheduledsparkapplications
+{{ end -}}
 {{ end -}}
 {{- end }}
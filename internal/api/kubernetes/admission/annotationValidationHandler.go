@@ -0,0 +1,176 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/notification"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/tracing"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const downscalerAnnotationPrefix = "downscaler/"
+
+// AnnotationValidationHandler validates the downscaler annotations of workloads and namespaces.
+type AnnotationValidationHandler struct {
+	warnOnly bool
+	dryRun   bool
+}
+
+// NewAnnotationValidationHandler creates a new AnnotationValidationHandler.
+// If warnOnly is set, invalid annotations are allowed with a warning instead of being rejected.
+func NewAnnotationValidationHandler(warnOnly, dryRun bool) *AnnotationValidationHandler {
+	return &AnnotationValidationHandler{
+		warnOnly: warnOnly,
+		dryRun:   dryRun,
+	}
+}
+
+// HandleAnnotationValidation handles the validation of the downscaler annotations of an object.
+// The span of the request continues the trace propagated by the Kubernetes API server, if there is one.
+func (a *AnnotationValidationHandler) HandleAnnotationValidation(ctx context.Context, writer http.ResponseWriter, request *http.Request) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(request.Header))
+
+	ctx, span := tracing.Start(ctx, "annotation validation request")
+	defer span.End()
+
+	input, err := parseAdmissionReviewFromRequest(request)
+	if err != nil {
+		slog.Error("error encountered while parsing the request", "error", err)
+		tracing.RecordError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("admission.uid", string(input.Request.UID)),
+		attribute.String("admission.operation", string(input.Request.Operation)),
+		attribute.String("object.kind", input.Request.Kind.Kind),
+		attribute.String("object.namespace", input.Request.Namespace),
+		attribute.String("object.name", input.Request.Name),
+	)
+
+	out := a.evaluateAnnotations(ctx, input.Request)
+
+	span.SetAttributes(attribute.Bool("admission.allowed", out.Response.Allowed))
+
+	sendAdmissionReviewResponse(writer, out)
+}
+
+// evaluateAnnotations validates the downscaler annotations of the object in the request and returns an AdmissionReview.
+func (a *AnnotationValidationHandler) evaluateAnnotations(
+	ctx context.Context,
+	request *admissionv1.AdmissionRequest,
+) *admissionv1.AdmissionReview {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return newReviewResponse(request.UID, true, http.StatusOK, "only creations and updates are validated", false, false)
+	}
+
+	object, err := parseObjectMetadata(request.Object.Raw)
+	if err != nil {
+		slog.Error("failed to parse the metadata of the object", "error", err, "kind", request.Kind.Kind, "name", request.Name)
+
+		return newReviewResponse(request.UID, true, http.StatusOK, "failed to parse the metadata of the object", true, false)
+	}
+
+	annotations := getDownscalerAnnotations(object.GetAnnotations())
+	if len(annotations) == 0 {
+		return newReviewResponse(request.UID, true, http.StatusOK, "object has no downscaler annotations", false, false)
+	}
+
+	// objects which already have invalid annotations shouldn't be blocked from unrelated updates
+	if request.Operation == admissionv1.Update {
+		oldObject, err := parseObjectMetadata(request.OldObject.Raw)
+		if err == nil && maps.Equal(annotations, getDownscalerAnnotations(oldObject.GetAnnotations())) {
+			return newReviewResponse(request.UID, true, http.StatusOK, "downscaler annotations didn't change", false, false)
+		}
+	}
+
+	ignored, err := values.ValidateAnnotations(annotations, ctx)
+	if err == nil {
+		warnings := append(getUnknownAnnotationWarnings(annotations), ignored...)
+		if len(warnings) == 0 {
+			return newReviewResponse(request.UID, true, http.StatusOK, "downscaler annotations are valid", false, false)
+		}
+
+		// unknown and ignored annotations don't break the downscaler, so they are only warned about
+		reason := fmt.Sprintf("ignored downscaler annotations on %s %q: %s", request.Kind.Kind, object.GetName(), strings.Join(warnings, "; "))
+
+		return newReviewResponse(request.UID, true, http.StatusOK, reason, true, false)
+	}
+
+	reason := fmt.Sprintf("invalid downscaler annotations on %s %q: %v", request.Kind.Kind, object.GetName(), err)
+
+	slog.Info(
+		"found invalid downscaler annotations",
+		"kind", request.Kind.Kind,
+		"namespace", request.Namespace,
+		"name", object.GetName(),
+		"error", err,
+		"warnOnly", a.warnOnly,
+		"dryRun", a.dryRun,
+	)
+
+	if a.warnOnly {
+		return newReviewResponse(request.UID, true, http.StatusOK, reason, true, a.dryRun)
+	}
+
+	// in dry-run mode the request is allowed, so the reason is shown as a warning instead
+	return newReviewResponse(request.UID, false, http.StatusUnprocessableEntity, reason, a.dryRun, a.dryRun)
+}
+
+// parseObjectMetadata parses the metadata of a raw object of any kind.
+func parseObjectMetadata(raw []byte) (*metav1.PartialObjectMetadata, error) {
+	var object metav1.PartialObjectMetadata
+
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, newFailedToParseRequestError("failed to parse object metadata", err)
+	}
+
+	return &object, nil
+}
+
+// getDownscalerAnnotations returns the downscaler annotations set by users out of the given annotations.
+// The state managed by the downscaler itself is left out, so changes of it are never validated.
+func getDownscalerAnnotations(annotations map[string]string) map[string]string {
+	downscalerAnnotations := make(map[string]string)
+
+	for key, value := range annotations {
+		if strings.HasPrefix(key, downscalerAnnotationPrefix) && !scalable.IsDownscalerStateAnnotation(key) {
+			downscalerAnnotations[key] = value
+		}
+	}
+
+	return downscalerAnnotations
+}
+
+// getUnknownAnnotationWarnings returns a warning for every downscaler annotation which isn't read by the downscaler, e.g. typos.
+func getUnknownAnnotationWarnings(annotations map[string]string) []string {
+	warnings := make([]string, 0, len(annotations))
+
+	for key := range annotations {
+		if values.IsScopeAnnotation(key) || kubernetes.IsControlAnnotation(key) || key == notification.AnnotationNotificationURLs {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf("unknown annotation %q", key))
+	}
+
+	slices.Sort(warnings)
+
+	return warnings
+}
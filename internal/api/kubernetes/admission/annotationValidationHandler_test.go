@@ -0,0 +1,191 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func newAnnotatedObject(t *testing.T, annotations map[string]string) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Annotations: annotations},
+	})
+	require.NoError(t, err)
+
+	return raw
+}
+
+func newAnnotationValidationRequest(t *testing.T, operation admissionv1.Operation, object, oldObject []byte) *http.Request {
+	t.Helper()
+
+	admissionReview := admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "1234",
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Namespace: "team-a",
+			Name:      "web",
+			Operation: operation,
+			Object:    k8sruntime.RawExtension{Raw: object},
+			OldObject: k8sruntime.RawExtension{Raw: oldObject},
+		},
+	}
+	body, err := json.Marshal(admissionReview)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/validate-annotations", bytes.NewBuffer(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	return request
+}
+
+func TestHandleAnnotationValidation(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]string{"downscaler/uptime": "Mon-Fri 07:00-19:00 Europe/Berlin"}
+	invalid := map[string]string{"downscaler/uptime": "Mon-Fri 7:00-19:00"}
+	incompatible := map[string]string{
+		"downscaler/uptime":   "Mon-Fri 07:00-19:00 Europe/Berlin",
+		"downscaler/downtime": "Sat-Sun 00:00-24:00 Europe/Berlin",
+	}
+
+	tests := []struct {
+		name         string
+		operation    admissionv1.Operation
+		annotations  map[string]string
+		oldObject    map[string]string
+		warnOnly     bool
+		dryRun       bool
+		wantAllowed  bool
+		wantWarning  bool
+		wantContains string
+	}{
+		{
+			name:        "no downscaler annotations",
+			operation:   admissionv1.Create,
+			annotations: map[string]string{"team": "a"},
+			wantAllowed: true,
+		},
+		{
+			name:        "valid annotations",
+			operation:   admissionv1.Create,
+			annotations: valid,
+			wantAllowed: true,
+		},
+		{
+			name:         "invalid annotation is rejected",
+			operation:    admissionv1.Create,
+			annotations:  invalid,
+			wantAllowed:  false,
+			wantContains: `failed to parse "downscaler/uptime" annotation`,
+		},
+		{
+			name:         "incompatible annotations are rejected",
+			operation:    admissionv1.Update,
+			annotations:  incompatible,
+			oldObject:    valid,
+			wantAllowed:  false,
+			wantContains: "found incompatible fields",
+		},
+		{
+			name:         "invalid annotation is allowed with a warning",
+			operation:    admissionv1.Create,
+			annotations:  invalid,
+			warnOnly:     true,
+			wantAllowed:  true,
+			wantWarning:  true,
+			wantContains: `failed to parse "downscaler/uptime" annotation`,
+		},
+		{
+			name:         "invalid annotation is allowed in dry run mode",
+			operation:    admissionv1.Create,
+			annotations:  invalid,
+			dryRun:       true,
+			wantAllowed:  true,
+			wantWarning:  true,
+			wantContains: "(dry-run mode)",
+		},
+		{
+			name:        "unchanged invalid annotations don't block updates",
+			operation:   admissionv1.Update,
+			annotations: invalid,
+			oldObject:   invalid,
+			wantAllowed: true,
+		},
+		{
+			name:      "changed downscaler state doesn't validate unchanged invalid annotations",
+			operation: admissionv1.Update,
+			annotations: map[string]string{
+				"downscaler/uptime":            "Mon-Fri 7:00-19:00",
+				"downscaler/original-replicas": "3",
+				"downscaler/downscale-record":  `{"replicas":0}`,
+				"downscaler/wake-until":        "2026-10-19T20:00:00Z",
+			},
+			oldObject:   invalid,
+			wantAllowed: true,
+		},
+		{
+			name:         "ignored invalid annotation is allowed with a warning",
+			operation:    admissionv1.Create,
+			annotations:  map[string]string{"downscaler/upscale-excluded": "yes please"},
+			wantAllowed:  true,
+			wantWarning:  true,
+			wantContains: "ignored downscaler annotations",
+		},
+		{
+			name:         "unknown annotation is allowed with a warning",
+			operation:    admissionv1.Create,
+			annotations:  map[string]string{"downscaler/uptim": "Mon-Fri 07:00-19:00 Europe/Berlin"},
+			wantAllowed:  true,
+			wantWarning:  true,
+			wantContains: `unknown annotation "downscaler/uptim"`,
+		},
+		{
+			name:      "known annotations aren't warned about",
+			operation: admissionv1.Create,
+			annotations: map[string]string{
+				"downscaler/uptime":            "Mon-Fri 07:00-19:00 Europe/Berlin",
+				"downscaler/notification-urls": "https://hooks.example.com/team-a",
+				"downscaler/global-mode":       "resume",
+			},
+			wantAllowed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var oldObject []byte
+			if test.oldObject != nil {
+				oldObject = newAnnotatedObject(t, test.oldObject)
+			}
+
+			request := newAnnotationValidationRequest(t, test.operation, newAnnotatedObject(t, test.annotations), oldObject)
+			recorder := httptest.NewRecorder()
+
+			NewAnnotationValidationHandler(test.warnOnly, test.dryRun).HandleAnnotationValidation(t.Context(), recorder, request)
+
+			var review admissionv1.AdmissionReview
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &review))
+			require.NotNil(t, review.Response)
+			assert.Equal(t, "1234", string(review.Response.UID))
+			assert.Equal(t, test.wantAllowed, review.Response.Allowed)
+			assert.Equal(t, test.wantWarning, len(review.Response.Warnings) > 0)
+
+			if test.wantContains != "" {
+				assert.Contains(t, review.Response.Result.Message, test.wantContains)
+			}
+		})
+	}
+}
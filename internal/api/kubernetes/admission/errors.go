@@ -76,6 +76,21 @@ func (s *ScalingInvalidError) Error() string {
 	return s.message
 }
 
+type InvalidManualUpscalePolicyError struct {
+	policy string
+}
+
+func newInvalidManualUpscalePolicyError(policy string) error {
+	return &InvalidManualUpscalePolicyError{policy: policy}
+}
+
+func (i *InvalidManualUpscalePolicyError) Error() string {
+	return fmt.Sprintf(
+		"error: invalid manual upscale policy %q, has to be either %q or %q",
+		i.policy, ManualUpscalePolicyRewrite, ManualUpscalePolicyDeny,
+	)
+}
+
 // ErrNoExternalScaling is a sentinel error.
 var ErrNoExternalScaling = &NoExternalScalingError{"no external scaling decision"}
 
@@ -0,0 +1,56 @@
+package admission
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+// globalModeCacheTTL is how long the global mode is cached before it is retrieved again.
+const globalModeCacheTTL = 10 * time.Second
+
+// globalModeCache caches the global mode of the downscaler, so not every admission request has to get the downscaler's namespace.
+type globalModeCache struct {
+	client    kubernetes.Client
+	ttl       time.Duration
+	mutex     sync.Mutex
+	mode      values.GlobalMode
+	fetched   bool
+	fetchedAt time.Time
+}
+
+// newGlobalModeCache creates a new globalModeCache which retrieves the global mode once it is older than the ttl.
+func newGlobalModeCache(client kubernetes.Client, ttl time.Duration) *globalModeCache {
+	return &globalModeCache{
+		client: client,
+		ttl:    ttl,
+		mode:   values.GlobalModeResume,
+	}
+}
+
+// get gets the cached global mode and retrieves it again if it expired.
+// If the global mode can't be retrieved, the previous mode is kept until the ttl expired again.
+func (g *globalModeCache) get(ctx context.Context) values.GlobalMode {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.fetched && time.Since(g.fetchedAt) < g.ttl {
+		return g.mode
+	}
+
+	mode, err := g.client.GetGlobalMode(ctx)
+	if err != nil {
+		slog.Error("failed to get global mode, keeping the previous mode", "error", err, "mode", g.mode)
+	} else {
+		g.mode = mode
+	}
+
+	g.fetched = true
+	g.fetchedAt = time.Now()
+
+	return g.mode
+}
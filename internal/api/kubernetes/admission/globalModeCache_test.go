@@ -0,0 +1,68 @@
+package admission
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalModeCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mode is cached until the ttl expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		mockClient := new(MockClient)
+		mockClient.On("GetGlobalMode", ctx).Return(values.GlobalModePause, nil)
+
+		cache := newGlobalModeCache(mockClient, time.Hour)
+
+		assert.Equal(t, values.GlobalModePause, cache.get(ctx))
+		assert.Equal(t, values.GlobalModePause, cache.get(ctx))
+		mockClient.AssertNumberOfCalls(t, "GetGlobalMode", 1)
+	})
+
+	t.Run("expired mode is retrieved again", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		mockClient := new(MockClient)
+		mockClient.On("GetGlobalMode", ctx).Return(values.GlobalModeUpscale, nil)
+
+		cache := newGlobalModeCache(mockClient, 0)
+
+		assert.Equal(t, values.GlobalModeUpscale, cache.get(ctx))
+		assert.Equal(t, values.GlobalModeUpscale, cache.get(ctx))
+		mockClient.AssertNumberOfCalls(t, "GetGlobalMode", 2)
+	})
+
+	t.Run("previous mode is kept if the mode can't be retrieved", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		mockClient := new(MockClient)
+		mockClient.On("GetGlobalMode", ctx).Return(values.GlobalModePause, nil).Once()
+		mockClient.On("GetGlobalMode", ctx).Return(values.GlobalModeResume, errors.New("connection refused"))
+
+		cache := newGlobalModeCache(mockClient, 0)
+
+		assert.Equal(t, values.GlobalModePause, cache.get(ctx))
+		assert.Equal(t, values.GlobalModePause, cache.get(ctx))
+	})
+
+	t.Run("resume is used if the mode was never retrieved", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		mockClient := new(MockClient)
+		mockClient.On("GetGlobalMode", ctx).Return(values.GlobalModeResume, errors.New("connection refused"))
+
+		cache := newGlobalModeCache(mockClient, time.Hour)
+
+		assert.Equal(t, values.GlobalModeResume, cache.get(ctx))
+	})
+}
@@ -0,0 +1,242 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	admissionv1 "k8s.io/api/admission/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scaleSubresource is the subresource HPAs, KEDA and kubectl scale change the replicas of workloads with.
+const scaleSubresource = "scale"
+
+// getScaleTargetKinds gets the kinds of the workloads whose scale subresource is handled, by their resource.
+// Only workloads which store their original replicas and are scaled through spec.replicas are handled.
+// Other kinds with a scale subresource (e.g. Prometheuses or Stacks) aren't intercepted, so they are only corrected
+// by the downscaler in its next scan cycle.
+func getScaleTargetKinds() map[string]string {
+	return map[string]string{
+		"deployments":  "deployment",
+		"statefulsets": "statefulset",
+		"rollouts":     "rollout",
+	}
+}
+
+// evaluateScaleMutation evaluates a request on the scale subresource of a workload and returns an AdmissionReview.
+// Scales which would upscale a workload that is downscaled by the downscaler are denied or kept at the downscaled replicas,
+// depending on the manual upscale policy. When they are kept, the requested replicas are stored as the original replicas
+// of the workload, so it is upscaled to them at the end of the downtime.
+func (v *WorkloadMutationHandler) evaluateScaleMutation(
+	ctx context.Context,
+	review *admissionv1.AdmissionReview,
+) (*admissionv1.AdmissionReview, error) {
+	request := review.Request
+
+	kind, ok := getScaleTargetKinds()[request.Resource.Resource]
+	if _, included := v.includeResourcesSet[request.Resource.Resource]; !ok || !included {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, false, request.Namespace)
+
+		return newReviewResponse(
+			request.UID,
+			true,
+			http.StatusAccepted,
+			fmt.Sprintf("scale subresource of %q isn't managed by the downscaler", request.Resource.Resource),
+			false,
+			v.dryRun,
+		), nil
+	}
+
+	scale, oldScale, err := parseScales(request)
+	if err != nil {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, true, request.Namespace)
+
+		return newReviewResponse(request.UID, true, http.StatusAccepted, "failed to parse scale from raw object", true, v.dryRun), err
+	}
+
+	workload, err := v.client.GetWorkload(kind, request.Namespace, request.Name, ctx)
+	if err != nil {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, true, request.Namespace)
+
+		return newReviewResponse(request.UID, true, http.StatusAccepted, "failed to get the scaled workload", true, v.dryRun), err
+	}
+
+	slog.Info(
+		"received scale request for workload",
+		"workload", workload.GetName(),
+		"namespace", workload.GetNamespace(),
+		"kind", workload.GroupVersionKind().Kind,
+		"replicas", scale.Spec.Replicas,
+	)
+
+	scopes, response, err := v.getWorkloadScopes(ctx, workload, review, v.metricsEnabled)
+	if response != nil {
+		return response, err
+	}
+
+	// workloads which aren't downscaled yet are left to the downscaler, so their original replicas are stored
+	if scopes.GetCurrentScaling() != values.ScalingDown || scalable.GetOriginalState(workload) == nil ||
+		scale.Spec.Replicas <= oldScale.Spec.Replicas {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, false, request.Namespace)
+
+		return newReviewResponse(
+			request.UID,
+			true,
+			http.StatusAccepted,
+			"scale doesn't upscale a downscaled workload",
+			false,
+			v.dryRun,
+		), nil
+	}
+
+	if v.manualUpscalePolicy == ManualUpscalePolicyDeny {
+		slog.Info("denying manual upscale of downscaled workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, false, request.Namespace)
+
+		return newManualUpscaleDeniedResponse(review, workload.GroupVersionKind().Kind, workload.GetName(), v.dryRun), nil
+	}
+
+	if scopeID, ok := scopes.GetDecidingScope(); ok {
+		ctx = audit.WithDecidingScope(ctx, scopeID.String())
+	}
+
+	err = v.storeRequestedReplicas(ctx, workload, request, scale)
+	if err != nil {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, true, request.Namespace)
+
+		return newReviewResponse(
+			request.UID,
+			false,
+			http.StatusInternalServerError,
+			"failed to store the requested replicas as the original replicas of the downscaled workload",
+			true,
+			v.dryRun,
+		), err
+	}
+
+	return v.mutateScale(ctx, workload, review, scale, oldScale.Spec.Replicas)
+}
+
+// storeRequestedReplicas stores the replicas requested through the scale subresource as the original replicas of the workload.
+// Nothing is stored for dry run requests, which is why the webhook is registered with "sideEffects: NoneOnDryRun".
+// Updates with a resource version, like the ones of HPAs and KEDA, only keep the workload downscaled: their replicas are
+// decided by a controller instead of a user, and changing the workload would make the update conflict.
+func (v *WorkloadMutationHandler) storeRequestedReplicas(
+	ctx context.Context,
+	workload scalable.Workload,
+	request *admissionv1.AdmissionRequest,
+	scale *autoscalingv1.Scale,
+) error {
+	if request.DryRun != nil && *request.DryRun {
+		slog.Info("dry run request, not storing the requested replicas", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+		return nil
+	}
+
+	if hasResourceVersionPrecondition(request, scale) {
+		slog.Info(
+			"scale was updated with a resource version, not storing the requested replicas",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+			"user", request.UserInfo.Username,
+		)
+
+		return nil
+	}
+
+	slog.Info(
+		"storing the requested replicas as the original replicas of the downscaled workload",
+		"workload", workload.GetName(),
+		"namespace", workload.GetNamespace(),
+		"replicas", scale.Spec.Replicas,
+	)
+
+	err := v.client.UpdateOriginalReplicas(workload, values.AbsoluteReplicas(scale.Spec.Replicas), ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store the requested replicas: %w", err)
+	}
+
+	return nil
+}
+
+// hasResourceVersionPrecondition checks if the request is an update of the scale which only succeeds if the workload wasn't changed
+// since it was read, like the updates of HPAs and KEDA. Patches, like the ones of kubectl scale, don't have a precondition.
+func hasResourceVersionPrecondition(request *admissionv1.AdmissionRequest, scale *autoscalingv1.Scale) bool {
+	if scale.ResourceVersion == "" {
+		return false
+	}
+
+	var options metav1.TypeMeta
+
+	if len(request.Options.Raw) > 0 && json.Unmarshal(request.Options.Raw, &options) == nil && options.Kind == "PatchOptions" {
+		return false
+	}
+
+	return true
+}
+
+// mutateScale keeps the scale at the given replicas and records the mutation in the audit log.
+func (v *WorkloadMutationHandler) mutateScale(
+	ctx context.Context,
+	workload scalable.Workload,
+	review *admissionv1.AdmissionReview,
+	scale *autoscalingv1.Scale,
+	replicas int32,
+) (*admissionv1.AdmissionReview, error) {
+	// the patch is built by hand, since comparing the scales would remove the replicas field when they are 0
+	patch := jsondiff.Patch{{
+		Type:     jsondiff.OperationReplace,
+		Path:     "/spec/replicas",
+		Value:    replicas,
+		OldValue: scale.Spec.Replicas,
+	}}
+
+	jsonPatch, err := json.Marshal(patch)
+	if err != nil {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, true, workload.GetNamespace())
+
+		return newReviewResponse(review.Request.UID, true, http.StatusAccepted, "failed to marshal patch", true, v.dryRun), err
+	}
+
+	if v.dryRun {
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, false, workload.GetNamespace())
+
+		return newReviewResponse(review.Request.UID, true, http.StatusAccepted, "would have patched scale", false, v.dryRun), nil
+	}
+
+	slog.Info(
+		"keeping downscaled workload at its replicas",
+		"workload", workload.GetName(),
+		"namespace", workload.GetNamespace(),
+		"replicas", replicas,
+	)
+
+	v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, true, false, workload.GetNamespace())
+	recordMutation(workload, review, patch, v.auditLog, ctx)
+
+	return newPatchReviewResponse(review.Request.UID, jsonPatch)
+}
+
+// parseScales parses the scale of the request and the scale it replaces.
+//
+//nolint:nonamedreturns // using named return values for clarity
+func parseScales(request *admissionv1.AdmissionRequest) (scale, oldScale *autoscalingv1.Scale, err error) {
+	scale = &autoscalingv1.Scale{}
+	if err = json.Unmarshal(request.Object.Raw, scale); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode scale: %w", err)
+	}
+
+	oldScale = &autoscalingv1.Scale{}
+	if err = json.Unmarshal(request.OldObject.Raw, oldScale); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode old scale: %w", err)
+	}
+
+	return scale, oldScale, nil
+}
@@ -0,0 +1,225 @@
+package admission
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func newScaleJSON(t *testing.T, replicas int32, resourceVersion string) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(autoscalingv1.Scale{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deploy", Namespace: "default", ResourceVersion: resourceVersion},
+		Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+	})
+	require.NoError(t, err)
+
+	return raw
+}
+
+func TestEvaluateScaleMutation(t *testing.T) {
+	t.Parallel()
+
+	downscaled := `"downscaler/original-replicas":"3"`
+
+	tests := []struct {
+		name            string
+		resource        string
+		policy          string
+		workload        []byte
+		oldReplicas     int32
+		replicas        int32
+		resourceVersion string
+		options         string
+		username        string
+		dryRunRequest   bool
+		storeErr        error
+		expectedAllowed bool
+		expectedPatch   string
+		expectedMessage string
+		expectedStored  bool
+	}{
+		{
+			name:            "upscale of downscaled workload is rewritten",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			expectedAllowed: true,
+			expectedPatch:   `[{"op":"replace","path":"/spec/replicas","value":0}]`,
+			expectedStored:  true,
+		},
+		{
+			name:            "dry run upscale is rewritten without storing the requested replicas",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			dryRunRequest:   true,
+			expectedAllowed: true,
+			expectedPatch:   `[{"op":"replace","path":"/spec/replicas","value":0}]`,
+		},
+		{
+			name:            "hpa update with a resource version is rewritten without storing the requested replicas",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			resourceVersion: "1234",
+			options:         `{"kind":"UpdateOptions","apiVersion":"meta.k8s.io/v1"}`,
+			username:        "system:serviceaccount:kube-system:horizontal-pod-autoscaler",
+			expectedAllowed: true,
+			expectedPatch:   `[{"op":"replace","path":"/spec/replicas","value":0}]`,
+		},
+		{
+			name:            "kubectl scale patch stores the requested replicas",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			resourceVersion: "1234",
+			options:         `{"kind":"PatchOptions","apiVersion":"meta.k8s.io/v1"}`,
+			username:        "alice",
+			expectedAllowed: true,
+			expectedPatch:   `[{"op":"replace","path":"/spec/replicas","value":0}]`,
+			expectedStored:  true,
+		},
+		{
+			name:            "upscale is rejected if the requested replicas can't be stored",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			storeErr:        errors.New("conflict"),
+			expectedAllowed: false,
+			expectedMessage: "failed to store the requested replicas",
+			expectedStored:  true,
+		},
+		{
+			name:            "upscale of downscaled workload is denied",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyDeny,
+			workload:        newDeploymentJSON(0, downscaled),
+			oldReplicas:     0,
+			replicas:        5,
+			expectedAllowed: false,
+			expectedMessage: `add the "downscaler/wake-for" annotation`,
+		},
+		{
+			name:            "workload which isn't downscaled yet is left to the downscaler",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyRewrite,
+			workload:        newDeploymentJSON(3, ""),
+			oldReplicas:     3,
+			replicas:        5,
+			expectedAllowed: true,
+			expectedMessage: "scale doesn't upscale a downscaled workload",
+		},
+		{
+			name:            "downscale of downscaled workload is allowed",
+			resource:        "deployments",
+			policy:          ManualUpscalePolicyDeny,
+			workload:        newDeploymentJSON(1, downscaled),
+			oldReplicas:     1,
+			replicas:        0,
+			expectedAllowed: true,
+			expectedMessage: "scale doesn't upscale a downscaled workload",
+		},
+		{
+			name:            "resource which isn't managed is allowed",
+			resource:        "replicasets",
+			policy:          ManualUpscalePolicyDeny,
+			oldReplicas:     0,
+			replicas:        5,
+			expectedAllowed: true,
+			expectedMessage: "isn't managed by the downscaler",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			scope := values.NewScope()
+			scope.DownscaleReplicas = values.AbsoluteReplicas(0)
+			_ = scope.ForceDowntime.Set("true")
+
+			mockClient := &MockClient{}
+			mockClient.On("GetScaledObjects", "default", mock.Anything).Return([]scalable.Workload{}, nil)
+			mockClient.On("GetNamespaceScope", "default", mock.Anything).Return(scope, nil)
+			mockClient.On("UpdateOriginalReplicas", mock.Anything, values.AbsoluteReplicas(test.replicas), mock.Anything).Return(test.storeErr)
+
+			if test.workload != nil {
+				workload, err := scalable.ParseWorkloadFromRawObject("deployment", test.workload)
+				require.NoError(t, err)
+
+				mockClient.On("GetWorkload", "deployment", "default", "test-deploy", mock.Anything).Return(workload, nil)
+			}
+
+			handler := newHandlerWithMocks(mockClient)
+			handler.manualUpscalePolicy = test.policy
+
+			input := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:         "valid-uid",
+					Kind:        metav1.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"},
+					Resource:    metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: test.resource},
+					SubResource: "scale",
+					Namespace:   "default",
+					Name:        "test-deploy",
+					Operation:   admissionv1.Update,
+					Object:      k8sruntime.RawExtension{Raw: newScaleJSON(t, test.replicas, test.resourceVersion)},
+					OldObject:   k8sruntime.RawExtension{Raw: newScaleJSON(t, test.oldReplicas, test.resourceVersion)},
+					Options:     k8sruntime.RawExtension{Raw: []byte(test.options)},
+					UserInfo:    authenticationv1.UserInfo{Username: test.username},
+					DryRun:      &test.dryRunRequest,
+				},
+			}
+
+			resp, err := handler.evaluateScaleMutation(t.Context(), input)
+			if test.storeErr != nil {
+				require.ErrorIs(t, err, test.storeErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedAllowed, resp.Response.Allowed)
+
+			if test.expectedStored {
+				mockClient.AssertCalled(t, "UpdateOriginalReplicas", mock.Anything, values.AbsoluteReplicas(test.replicas), mock.Anything)
+			} else {
+				mockClient.AssertNotCalled(t, "UpdateOriginalReplicas", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if test.expectedPatch != "" {
+				require.JSONEq(t, test.expectedPatch, string(resp.Response.Patch))
+			}
+
+			if test.expectedMessage != "" {
+				require.Contains(t, resp.Response.Result.Message, test.expectedMessage)
+			}
+
+			if !test.expectedAllowed && test.storeErr == nil {
+				require.Equal(t, int32(http.StatusForbidden), resp.Response.Result.Code)
+			}
+		})
+	}
+}
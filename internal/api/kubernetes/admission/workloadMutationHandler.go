@@ -4,19 +4,44 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"slices"
 	"strings"
 
 	"github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/tracing"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	admissionv1 "k8s.io/api/admission/v1"
 )
 
+const (
+	// ManualUpscalePolicyRewrite keeps downscaled workloads down on manual upscales and stores the requested replicas as the original ones.
+	ManualUpscalePolicyRewrite = "rewrite"
+	// ManualUpscalePolicyDeny denies manual upscales of downscaled workloads.
+	ManualUpscalePolicyDeny = "deny"
+
+	wakeForAnnotation = "downscaler/wake-for"
+)
+
+// CheckManualUpscalePolicy checks if the given manual upscale policy is supported.
+func CheckManualUpscalePolicy(policy string) error {
+	if policy != ManualUpscalePolicyRewrite && policy != ManualUpscalePolicyDeny {
+		return newInvalidManualUpscalePolicyError(policy)
+	}
+
+	return nil
+}
+
 // WorkloadMutationHandler is a struct that implements the admissionHandler interface.
 type WorkloadMutationHandler struct {
 	client              kubernetes.Client
@@ -28,9 +53,13 @@ type WorkloadMutationHandler struct {
 	includeLabels       *util.RegexList
 	excludeNamespaces   *util.RegexList
 	excludeWorkloads    *util.RegexList
+	protectionRules     *scalable.ProtectionRules
 	includeResourcesSet map[string]struct{}
 	metricsEnabled      bool
 	admissionMetrics    *metrics.AdmissionMetrics
+	auditLog            *audit.Log
+	manualUpscalePolicy string
+	globalModeCache     *globalModeCache
 }
 
 // NewWorkloadMutationHandler creates a new WorkloadMutationHandler.
@@ -40,9 +69,12 @@ func NewWorkloadMutationHandler(
 	dryRun bool,
 	includeNamespaces *[]string,
 	includeLabels, excludeNamespaces, excludeWorkloads *util.RegexList,
+	protectionRules *scalable.ProtectionRules,
 	includeResources map[string]struct{},
 	metricsEnabled bool,
 	admissionMetrics *metrics.AdmissionMetrics,
+	auditLog *audit.Log,
+	manualUpscalePolicy string,
 ) *WorkloadMutationHandler {
 	return &WorkloadMutationHandler{
 		client:              client,
@@ -54,22 +86,73 @@ func NewWorkloadMutationHandler(
 		includeLabels:       includeLabels,
 		excludeNamespaces:   excludeNamespaces,
 		excludeWorkloads:    excludeWorkloads,
+		protectionRules:     protectionRules,
 		includeResourcesSet: includeResources,
 		metricsEnabled:      metricsEnabled,
 		admissionMetrics:    admissionMetrics,
+		auditLog:            auditLog,
+		manualUpscalePolicy: manualUpscalePolicy,
+		globalModeCache:     newGlobalModeCache(client, globalModeCacheTTL),
 	}
 }
 
 // HandleWorkloadMutation handles the validation of a workload.
+// The span of the request continues the trace propagated by the Kubernetes API server, if there is one.
 func (v *WorkloadMutationHandler) HandleWorkloadMutation(ctx context.Context, writer http.ResponseWriter, request *http.Request) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(request.Header))
+
+	ctx, span := tracing.Start(ctx, "admission request")
+	defer span.End()
+
 	input, err := parseAdmissionReviewFromRequest(request)
 	if err != nil {
 		slog.Error("error encountered while parsing the request", "error", err)
+		tracing.RecordError(span, err)
 		http.Error(writer, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
+	span.SetAttributes(
+		attribute.String("admission.uid", string(input.Request.UID)),
+		attribute.String("admission.operation", string(input.Request.Operation)),
+		attribute.String("workload.kind", input.Request.Kind.Kind),
+		attribute.String("workload.namespace", input.Request.Namespace),
+		attribute.String("workload.name", input.Request.Name),
+	)
+
+	globalMode := v.globalModeCache.get(ctx)
+	if !globalMode.IsActive() {
+		slog.Info("skipping mutation because of the global mode", "mode", globalMode, "namespace", input.Request.Namespace)
+		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(v.metricsEnabled, false, false, input.Request.Namespace)
+
+		response := newReviewResponse(
+			input.Request.UID,
+			true,
+			http.StatusAccepted,
+			fmt.Sprintf("the global mode of the downscaler is %q, doesn't need mutation", globalMode),
+			false,
+			v.dryRun,
+		)
+		sendAdmissionReviewResponse(writer, response)
+
+		return
+	}
+
+	if input.Request.SubResource == scaleSubresource {
+		out, err := v.evaluateScaleMutation(ctx, input)
+		if err != nil {
+			slog.Error("error encountered while validating scale", "error", err)
+			tracing.RecordError(span, err)
+		}
+
+		span.SetAttributes(attribute.Bool("admission.patched", out.Response.Patch != nil))
+
+		sendAdmissionReviewResponse(writer, out)
+
+		return
+	}
+
 	workload, err := scalable.ParseWorkloadFromRawObject(strings.ToLower(input.Request.Kind.Kind), input.Request.Object.Raw)
 	if err != nil {
 		slog.Error("error encountered while parsing the workload", "error", err)
@@ -97,11 +180,14 @@ func (v *WorkloadMutationHandler) HandleWorkloadMutation(ctx context.Context, wr
 	out, err := v.evaluateWorkloadMutation(ctx, workload, input, v.metricsEnabled)
 	if err != nil {
 		slog.Error("error encountered while validating workload", "error", err)
+		tracing.RecordError(span, err)
 		sendAdmissionReviewResponse(writer, out)
 
 		return
 	}
 
+	span.SetAttributes(attribute.Bool("admission.patched", out.Response.Patch != nil))
+
 	sendAdmissionReviewResponse(writer, out)
 }
 
@@ -112,6 +198,40 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 	review *admissionv1.AdmissionReview,
 	metricsEnabled bool,
 ) (*admissionv1.AdmissionReview, error) {
+	scopes, response, err := v.getWorkloadScopes(ctx, workload, review, metricsEnabled)
+	if response != nil {
+		return response, err
+	}
+
+	scaling := scopes.GetCurrentScaling()
+
+	response, err = evaluateWorkloadScalingConditions(
+		scaling,
+		workload,
+		scopes,
+		review,
+		v.dryRun,
+		v.manualUpscalePolicy,
+		metricsEnabled,
+		v.admissionMetrics,
+		v.auditLog,
+		ctx,
+	)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// getWorkloadScopes gets the scopes of the workload.
+// If the workload doesn't need to be evaluated any further, the AdmissionReview to respond with is returned instead.
+func (v *WorkloadMutationHandler) getWorkloadScopes(
+	ctx context.Context,
+	workload scalable.Workload,
+	review *admissionv1.AdmissionReview,
+	metricsEnabled bool,
+) (values.Scopes, *admissionv1.AdmissionReview, error) {
 	resourceLogger := kubernetes.NewResourceLoggerForWorkload(v.client, workload)
 
 	slog.Info("evaluating mutation on workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
@@ -129,7 +249,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, false, workload.GetNamespace())
 
-		return newReviewResponse(
+		return values.Scopes{}, newReviewResponse(
 			review.Request.UID, true,
 			http.StatusAccepted,
 			"workload namespace is not in the list of included namespaces, excluding it from downscaling",
@@ -148,12 +268,19 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 		slog.Info("workload is controlled by keda scaledobjects, excluding it")
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, false, workload.GetNamespace())
 
-		return externalScalingReview, err
+		return values.Scopes{}, externalScalingReview, err
 	}
 
-	slog.Debug("checking labels, excluded namespaces and excluded workloads")
+	slog.Debug("checking labels, excluded namespaces, excluded workloads and protection rules")
 
-	workloads := scalable.FilterExcluded(workloadArray, *v.includeLabels, *v.excludeNamespaces, *v.excludeWorkloads, nil)
+	workloads := scalable.FilterExcluded(
+		workloadArray,
+		*v.includeLabels,
+		*v.excludeNamespaces,
+		*v.excludeWorkloads,
+		v.protectionRules,
+		nil,
+	)
 
 	if len(workloads) == 0 {
 		slog.Info(
@@ -165,7 +292,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, false, workload.GetNamespace())
 
-		return newReviewResponse(
+		return values.Scopes{}, newReviewResponse(
 			review.Request.UID,
 			true,
 			http.StatusAccepted,
@@ -198,7 +325,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, true, workload.GetNamespace())
 
-		return newReviewResponse(
+		return values.Scopes{}, newReviewResponse(
 			review.Request.UID,
 			true,
 			http.StatusAccepted,
@@ -226,7 +353,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, true, workload.GetNamespace())
 
-		return newReviewResponse(
+		return values.Scopes{}, newReviewResponse(
 			review.Request.UID,
 			true,
 			http.StatusAccepted,
@@ -249,7 +376,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 
 		v.admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, false, workload.GetNamespace())
 
-		return newReviewResponse(
+		return values.Scopes{}, newReviewResponse(
 			review.Request.UID,
 			true,
 			http.StatusAccepted,
@@ -259,14 +386,7 @@ func (v *WorkloadMutationHandler) evaluateWorkloadMutation(
 		), nil
 	}
 
-	scaling := scopes.GetCurrentScaling()
-
-	response, err := evaluateWorkloadScalingConditions(scaling, workload, scopes, review, v.dryRun, metricsEnabled, v.admissionMetrics)
-	if err != nil {
-		return response, err
-	}
-
-	return response, nil
+	return scopes, nil, nil
 }
 
 // evaluateWorkloadScalingConditions scales the given workload according to the given wanted scaling state.
@@ -276,8 +396,11 @@ func evaluateWorkloadScalingConditions(
 	scopes values.Scopes,
 	review *admissionv1.AdmissionReview,
 	dryRun bool,
+	manualUpscalePolicy string,
 	metricsEnabled bool,
 	admissionMetrics *metrics.AdmissionMetrics,
+	auditLog *audit.Log,
+	ctx context.Context,
 ) (*admissionv1.AdmissionReview, error) {
 	if scaling == values.ScalingNone {
 		slog.Debug(
@@ -391,7 +514,22 @@ setting different scaling states at the same time (e.g. downtime-period and upti
 			), err
 		}
 
-		response, err := mutateWorkload(workload, review, downscaleReplicas, dryRun, metricsEnabled, admissionMetrics)
+		if manualUpscalePolicy == ManualUpscalePolicyDeny && isManualUpscale(workload, review, downscaleReplicas) {
+			slog.Info("denying manual upscale of downscaled workload",
+				"workload", workload.GetName(),
+				"namespace", workload.GetNamespace(),
+				"dryRun", dryRun)
+
+			admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, false, false, workload.GetNamespace())
+
+			return newManualUpscaleDeniedResponse(review, review.Request.Kind.Kind, workload.GetName(), dryRun), nil
+		}
+
+		if scopeID, ok := scopes.GetDecidingScope(); ok {
+			ctx = audit.WithDecidingScope(ctx, scopeID.String())
+		}
+
+		response, err := mutateWorkload(workload, review, downscaleReplicas, dryRun, metricsEnabled, admissionMetrics, auditLog, ctx)
 		if err != nil {
 			return response, err
 		}
@@ -474,7 +612,46 @@ func (v *WorkloadMutationHandler) evaluateWorkloadExternalScalingCondition(
 	return nil, ErrNoExternalScaling
 }
 
-// mutateWorkload mutates the workload by scaling it down based on the scopes.
+// newManualUpscaleDeniedResponse returns an AdmissionReview denying the manual upscale of a downscaled workload.
+func newManualUpscaleDeniedResponse(review *admissionv1.AdmissionReview, kind, name string, dryRun bool) *admissionv1.AdmissionReview {
+	return newReviewResponse(
+		review.Request.UID,
+		false,
+		http.StatusForbidden,
+		fmt.Sprintf(
+			"%s %q is downscaled by the downscaler, add the %q annotation to wake it up temporarily instead of scaling it up manually",
+			kind,
+			name,
+			wakeForAnnotation,
+		),
+		dryRun,
+		dryRun,
+	)
+}
+
+// isManualUpscale checks if the request is an update which scales up a workload that was already downscaled.
+func isManualUpscale(workload scalable.Workload, review *admissionv1.AdmissionReview, downscaleReplicas values.Replicas) bool {
+	if review.Request.Operation != admissionv1.Update {
+		return false
+	}
+
+	oldWorkload, err := scalable.ParseWorkloadFromRawObject(strings.ToLower(review.Request.Kind.Kind), review.Request.OldObject.Raw)
+	if err != nil || scalable.GetOriginalState(oldWorkload) == nil {
+		return false
+	}
+
+	// the workload is scaled up by the request if it would have to be scaled down again
+	workloadCopy, err := workload.Copy()
+	if err != nil {
+		return false
+	}
+
+	_, changed, err := workloadCopy.ScaleDown(downscaleReplicas)
+
+	return err == nil && changed
+}
+
+// mutateWorkload mutates the workload by scaling it down based on the scopes and records the mutation in the audit log.
 func mutateWorkload(
 	workload scalable.Workload,
 	review *admissionv1.AdmissionReview,
@@ -482,6 +659,8 @@ func mutateWorkload(
 	dryRun bool,
 	metricsEnabled bool,
 	admissionMetrics *metrics.AdmissionMetrics,
+	auditLog *audit.Log,
+	ctx context.Context,
 ) (*admissionv1.AdmissionReview, error) {
 	// generate a deep copy of the workload to be able to generate a comparison patch
 	workloadCopy, err := workload.Copy()
@@ -551,6 +730,8 @@ func mutateWorkload(
 
 	if !dryRun {
 		admissionMetrics.UpdateValidateWorkloadAdmissionRequestsTotal(metricsEnabled, true, false, workload.GetNamespace())
+		recordMutation(workload, review, patch, auditLog, ctx)
+
 		return newPatchReviewResponse(review.Request.UID, jsonPatch)
 	}
 
@@ -565,3 +746,27 @@ func mutateWorkload(
 		dryRun,
 	), nil
 }
+
+// recordMutation writes the patch the workload is mutated with to the audit log.
+func recordMutation(
+	workload scalable.Workload,
+	review *admissionv1.AdmissionReview,
+	patch jsondiff.Patch,
+	auditLog *audit.Log,
+	ctx context.Context,
+) {
+	record := &audit.Record{
+		Actor:      audit.ActorWebhook,
+		Action:     audit.ActionDownscale,
+		Kind:       review.Request.Kind.Kind,
+		Namespace:  workload.GetNamespace(),
+		Name:       workload.GetName(),
+		Changes:    audit.NewChanges(patch),
+		RequestUID: string(review.Request.UID),
+	}
+
+	err := auditLog.Write(record, ctx)
+	if err != nil {
+		slog.Error("failed to write audit record", "error", err, "workload", workload.GetName(), "namespace", workload.GetNamespace())
+	}
+}
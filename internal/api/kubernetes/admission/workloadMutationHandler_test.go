@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
 	"testing"
 
 	client "github.com/caas-team/gokubedownscaler/internal/api/kubernetes"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
@@ -39,6 +41,21 @@ func (m *MockClient) GetScaledObjects(namespace string, ctx context.Context) ([]
 	return args.Get(0).([]scalable.Workload), args.Error(1)
 }
 
+func (m *MockClient) GetWorkload(kind, namespace, name string, ctx context.Context) (scalable.Workload, error) {
+	args := m.Called(kind, namespace, name, ctx)
+	return args.Get(0).(scalable.Workload), args.Error(1)
+}
+
+func (m *MockClient) UpdateOriginalReplicas(workload scalable.Workload, replicas values.Replicas, ctx context.Context) error {
+	args := m.Called(workload, replicas, ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) GetGlobalMode(ctx context.Context) (values.GlobalMode, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(values.GlobalMode), args.Error(1)
+}
+
 func newAdmissionRequests(t *testing.T, uid, kind, namespace string, rawJSON []byte) *http.Request {
 	t.Helper()
 
@@ -163,8 +180,11 @@ func newHandlerWithMocks(mockClient *MockClient) *WorkloadMutationHandler {
 		mockClient,
 		values.NewScope(), values.NewScope(), values.GetDefaultScope(),
 		false, nil, &util.RegexList{regexp.MustCompile(".*")}, &util.RegexList{}, &util.RegexList{},
+		&scalable.ProtectionRules{},
 		map[string]struct{}{"deployments": {}, "scaledobjects": {}}, false,
 		nil,
+		nil,
+		ManualUpscalePolicyRewrite,
 	)
 }
 
@@ -364,3 +384,172 @@ func TestEvaluateMutation(t *testing.T) {
 		})
 	}
 }
+
+func TestMutateWorkloadAuditLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		dryRun         bool
+		expectedRecord bool
+	}{
+		{
+			name:           "mutation is recorded",
+			dryRun:         false,
+			expectedRecord: true,
+		},
+		{
+			name:           "dry run is not recorded",
+			dryRun:         true,
+			expectedRecord: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			input, err := parseAdmissionReviewFromRequest(newDeploymentRequestWithLabels(t, "default"))
+			require.NoError(t, err)
+
+			workload, err := scalable.ParseWorkloadFromRawObject("deployment", input.Request.Object.Raw)
+			require.NoError(t, err)
+
+			var buffer bytes.Buffer
+
+			ctx := audit.WithDecidingScope(t.Context(), values.ScopeNamespace.String())
+
+			_, err = mutateWorkload(workload, input, values.AbsoluteReplicas(0), test.dryRun, false, nil, audit.NewLogWithWriter(&buffer), ctx)
+			require.NoError(t, err)
+
+			if !test.expectedRecord {
+				require.Empty(t, buffer.String())
+				return
+			}
+
+			var record audit.Record
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+			require.Equal(t, audit.ActorWebhook, record.Actor)
+			require.Equal(t, "Deployment", record.Kind)
+			require.Equal(t, "default", record.Namespace)
+			require.Equal(t, "test-deploy", record.Name)
+			require.Equal(t, "valid-uid", record.RequestUID)
+			require.Equal(t, values.ScopeNamespace.String(), record.DecidingScope)
+			require.Contains(t, record.Changes, audit.Change{Operation: "replace", Path: "/spec/replicas", Before: float64(1), After: float64(0)})
+		})
+	}
+}
+
+func newDeploymentJSON(replicas int, annotations string) []byte {
+	return fmt.Appendf(nil, `{
+		"apiVersion":"apps/v1",
+		"kind":"Deployment",
+		"metadata":{
+			"name":"test-deploy",
+			"namespace":"default",
+			"annotations":{%s},
+			"labels": {"app":"demo"}
+		},
+		"spec":{
+			"replicas":%d,
+			"selector":{"matchLabels":{"app":"demo"}},
+			"template":{
+				"metadata":{"labels":{"app":"demo"}},
+				"spec":{"containers":[{"name":"nginx","image":"nginx:1.21"}]}
+			}
+		}
+	}`, annotations, replicas)
+}
+
+func TestEvaluateManualUpscale(t *testing.T) {
+	t.Parallel()
+
+	downscaled := `"downscaler/original-replicas":"3"`
+
+	tests := []struct {
+		name            string
+		policy          string
+		oldObject       []byte
+		object          []byte
+		expectedAllowed bool
+		expectedPatched bool
+		expectedMessage string
+	}{
+		{
+			name:            "manual upscale is rewritten",
+			policy:          ManualUpscalePolicyRewrite,
+			oldObject:       newDeploymentJSON(0, downscaled),
+			object:          newDeploymentJSON(5, downscaled),
+			expectedAllowed: true,
+			expectedPatched: true,
+		},
+		{
+			name:            "manual upscale is denied",
+			policy:          ManualUpscalePolicyDeny,
+			oldObject:       newDeploymentJSON(0, downscaled),
+			object:          newDeploymentJSON(5, downscaled),
+			expectedAllowed: false,
+			expectedMessage: `add the "downscaler/wake-for" annotation`,
+		},
+		{
+			name:            "update without upscale is allowed",
+			policy:          ManualUpscalePolicyDeny,
+			oldObject:       newDeploymentJSON(0, downscaled),
+			object:          newDeploymentJSON(0, downscaled+`,"team":"a"`),
+			expectedAllowed: true,
+		},
+		{
+			name:            "workload which isn't downscaled yet is mutated",
+			policy:          ManualUpscalePolicyDeny,
+			oldObject:       newDeploymentJSON(3, ""),
+			object:          newDeploymentJSON(5, ""),
+			expectedAllowed: true,
+			expectedPatched: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			scope := values.NewScope()
+			scope.DownscaleReplicas = values.AbsoluteReplicas(0)
+			_ = scope.ForceDowntime.Set("true")
+
+			mockClient := &MockClient{}
+			mockClient.On("GetScaledObjects", "default", mock.Anything).Return([]scalable.Workload{}, nil)
+			mockClient.On("GetNamespaceScope", "default", mock.Anything).Return(scope, nil)
+
+			handler := newHandlerWithMocks(mockClient)
+			handler.manualUpscalePolicy = test.policy
+
+			input := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "valid-uid",
+					Kind:      metav1.GroupVersionKind{Kind: "Deployment"},
+					Namespace: "default",
+					Operation: admissionv1.Update,
+					Object:    k8sruntime.RawExtension{Raw: test.object},
+					OldObject: k8sruntime.RawExtension{Raw: test.oldObject},
+				},
+			}
+
+			workload, err := scalable.ParseWorkloadFromRawObject("deployment", test.object)
+			require.NoError(t, err)
+
+			resp, err := handler.evaluateWorkloadMutation(t.Context(), workload, input, false)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedAllowed, resp.Response.Allowed)
+
+			if test.expectedPatched {
+				require.Contains(t, string(resp.Response.Patch), "/spec/replicas")
+			}
+
+			if test.expectedMessage != "" {
+				require.Equal(t, int32(http.StatusForbidden), resp.Response.Result.Code)
+				require.Contains(t, resp.Response.Result.Message, test.expectedMessage)
+			}
+		})
+	}
+}
@@ -12,15 +12,16 @@ import (
 )
 
 type CertManager struct {
-	SecretName          string
-	CertDir             string
-	WebhookService      string
-	K8sClusterDomain    string
-	CAName              string
-	CAOrganization      string
-	MutatingWebhookName string
-	Ready               chan struct{}
-	Client              Client
+	SecretName            string
+	CertDir               string
+	WebhookService        string
+	K8sClusterDomain      string
+	CAName                string
+	CAOrganization        string
+	MutatingWebhookName   string
+	ValidatingWebhookName string
+	Ready                 chan struct{}
+	Client                Client
 }
 
 // AddCertificateRotation registers all needed services to generate the certificates and patches needed resources with the caBundle.
@@ -37,6 +38,13 @@ func (cm *CertManager) AddCertificateRotation(ctx context.Context, mgr manager.M
 		},
 	}
 
+	if cm.ValidatingWebhookName != "" {
+		webhookRotators = append(webhookRotators, rotator.WebhookInfo{
+			Name: cm.ValidatingWebhookName,
+			Type: rotator.Validating,
+		})
+	}
+
 	secretAlreadyPresent, err := cm.Client.ensureSecret(namespace, cm.SecretName, ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	stopDeploymentPollInterval = 2 * time.Second
+	stopDeploymentTimeout      = 2 * time.Minute
+)
+
+// StopDeployment scales the deployment in the format [namespace/]name to zero replicas and waits until all of its pods are gone.
+// An empty namespace defaults to the downscaler's namespace. Deployments which don't exist are skipped.
+func (c client) StopDeployment(deployment string, ctx context.Context) error {
+	namespace, name, found := strings.Cut(deployment, "/")
+	if !found {
+		name = deployment
+
+		var err error
+
+		namespace, err = getCurrentNamespace()
+		if err != nil {
+			return ErrUnknownNamespace
+		}
+	}
+
+	deployments := c.clientsets.Kubernetes.AppsV1().Deployments(namespace)
+
+	scale, err := deployments.GetScale(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		slog.Info("deployment doesn't exist, skipping", "deployment", name, "namespace", namespace)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get scale of deployment %s/%s: %w", namespace, name, err)
+	}
+
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have stopped deployment", "deployment", name, "namespace", namespace)
+		return nil
+	}
+
+	if scale.Spec.Replicas != 0 {
+		scale.Spec.Replicas = 0
+
+		_, err = deployments.UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to scale deployment %s/%s to zero: %w", namespace, name, err)
+		}
+	}
+
+	slog.Info("waiting for the pods of the deployment to stop", "deployment", name, "namespace", namespace)
+
+	err = wait.PollUntilContextTimeout(ctx, stopDeploymentPollInterval, stopDeploymentTimeout, true, func(ctx context.Context) (bool, error) {
+		current, err := deployments.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+		}
+
+		return current.Status.Replicas == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for deployment %s/%s to stop: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookConfiguration deletes the mutating and validating webhook configurations with the name.
+// Webhook configurations which don't exist are skipped.
+func (c client) DeleteWebhookConfiguration(name string, ctx context.Context) error {
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have deleted webhook configuration", "name", name)
+		return nil
+	}
+
+	admissionRegistration := c.clientsets.Kubernetes.AdmissionregistrationV1()
+
+	err := admissionRegistration.MutatingWebhookConfigurations().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete mutating webhook configuration %q: %w", name, err)
+	}
+
+	err = admissionRegistration.ValidatingWebhookConfigurations().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete validating webhook configuration %q: %w", name, err)
+	}
+
+	return nil
+}
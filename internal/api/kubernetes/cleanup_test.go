@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// newCleanupAPIServer creates a local stand-in for the api server with the deployment "downscaler/downscaler"
+// and the mutating webhook configuration "webhook.kube-downscaler.k8s". It records the requests it received.
+func newCleanupAPIServer(t *testing.T) (client, *[]string) {
+	t.Helper()
+
+	var (
+		mutex    sync.Mutex
+		requests []string
+		replicas int32 = 1
+	)
+
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, &metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /apis/apps/v1/namespaces/downscaler/deployments/downscaler/scale":
+			writeJSON(w, &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: replicas}})
+		case "PUT /apis/apps/v1/namespaces/downscaler/deployments/downscaler/scale":
+			var scale autoscalingv1.Scale
+			if err := json.NewDecoder(req.Body).Decode(&scale); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			replicas = scale.Spec.Replicas
+			writeJSON(w, &scale)
+		case "GET /apis/apps/v1/namespaces/downscaler/deployments/downscaler":
+			writeJSON(w, &appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: replicas}})
+		case "DELETE /apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations/webhook.kube-downscaler.k8s":
+			writeJSON(w, &metav1.Status{Status: metav1.StatusSuccess})
+		default:
+			notFound(w)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := &rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	return client{clientsets: &scalable.Clientsets{Kubernetes: clientset}}, &requests
+}
+
+func TestStopDeployment(t *testing.T) {
+	t.Parallel()
+
+	kubeclient, requests := newCleanupAPIServer(t)
+
+	require.NoError(t, kubeclient.StopDeployment("downscaler/downscaler", t.Context()))
+	require.NoError(t, kubeclient.StopDeployment("downscaler/missing", t.Context()), "missing deployments should be skipped")
+
+	assert.Contains(t, *requests, "PUT /apis/apps/v1/namespaces/downscaler/deployments/downscaler/scale")
+	assert.Contains(t, *requests, "GET /apis/apps/v1/namespaces/downscaler/deployments/downscaler")
+}
+
+func TestDeleteWebhookConfiguration(t *testing.T) {
+	t.Parallel()
+
+	kubeclient, requests := newCleanupAPIServer(t)
+
+	require.NoError(t, kubeclient.DeleteWebhookConfiguration("webhook.kube-downscaler.k8s", t.Context()))
+	require.NoError(t, kubeclient.DeleteWebhookConfiguration("missing", t.Context()), "missing configurations should be skipped")
+
+	assert.Contains(t, *requests, "DELETE /apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations/webhook.kube-downscaler.k8s")
+	assert.Contains(t, *requests, "DELETE /apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations/webhook.kube-downscaler.k8s")
+}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	stdErrors "errors"
 	"fmt"
 	"log/slog"
@@ -13,13 +14,17 @@ import (
 
 	actionsv1alpha1 "github.com/actions/actions-runner-controller/apis/actions.github.com/v1alpha1"
 	argo "github.com/argoproj/argo-rollouts/pkg/client/clientset/versioned"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/tracing"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	keda "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned"
 	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
 	zalando "github.com/zalando-incubator/stackset-controller/pkg/clientset"
 	acidv1 "github.com/zalando/postgres-operator/pkg/apis/acid.zalan.do/v1"
+	"go.opentelemetry.io/otel/attribute"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +43,7 @@ var (
 	ErrInvalidBurst = stdErrors.New("burst argument must greater than zero")
 	ErrInvalidQPS   = stdErrors.New("qps argument can't be zero, it can either be a positive value " +
 		"or a negative value to disable rate limiting")
+	ErrUnknownNamespace = stdErrors.New("the namespace of the downscaler can't be determined, a namespace has to be specified")
 )
 
 // Client is an interface representing a high-level client to get and modify Kubernetes resources.
@@ -53,34 +59,81 @@ type Client interface {
 	GetWorkloads(namespaces []string, resourceTypes []string, ctx context.Context) ([]scalable.Workload, error)
 	// RegetWorkload gets the workload again to ensure the latest state
 	RegetWorkload(workload scalable.Workload, ctx context.Context) error
-	// DownscaleWorkload downscales the workload to the specified replicas
-	DownscaleWorkload(replicas values.Replicas, workload scalable.Workload, ctx context.Context) (*metrics.SavedResources, error)
-	// UpscaleWorkload upscales the workload to the original replicas
-	UpscaleWorkload(workload scalable.Workload, ctx context.Context) error
+	// GetWorkload gets a single workload of the specified kind, e.g. "deployment"
+	GetWorkload(kind, namespace, name string, ctx context.Context) (scalable.Workload, error)
+	// DownscaleWorkload downscales the workload to the specified replicas, the cause is added to the event announcing the downscale
+	DownscaleWorkload(replicas values.Replicas, workload scalable.Workload, cause string, ctx context.Context) (*metrics.SavedResources, error)
+	// UpscaleWorkload upscales the workload to the original replicas, the cause is added to the event announcing the upscale
+	UpscaleWorkload(workload scalable.Workload, cause string, ctx context.Context) error
+	// CleanupWorkload upscales the workload and removes all annotations and selectors managed by the downscaler
+	CleanupWorkload(workload scalable.Workload, ctx context.Context) (bool, error)
+	// StopDeployment scales the deployment in the format [namespace/]name to zero and waits until its pods are gone
+	StopDeployment(deployment string, ctx context.Context) error
+	// DeleteWebhookConfiguration deletes the mutating and validating webhook configurations with the name
+	DeleteWebhookConfiguration(name string, ctx context.Context) error
+	// RepairDrift detects and repairs manual changes made to the downscaled workload according to the drift policy
+	RepairDrift(workload scalable.Workload, policy values.DriftPolicy, ctx context.Context) (*scalable.Drift, error)
+	// UpdateOriginalReplicas replaces the original replicas stored on the downscaled workload
+	UpdateOriginalReplicas(workload scalable.Workload, replicas values.Replicas, ctx context.Context) error
+	// UpdateWakeRequest replaces the wake request of the workload by the time it expires at or removes it if the time is nil
+	UpdateWakeRequest(workload scalable.Workload, wakeUntil *time.Time, ctx context.Context) error
+	// UpdateNamespaceWakeRequest replaces the wake request of the namespace by the time it expires at or removes it if the time is nil
+	UpdateNamespaceWakeRequest(namespace string, wakeUntil *time.Time, ctx context.Context) error
+	// ReviewToken authenticates the bearer token of a user, returns false if the token isn't valid
+	ReviewToken(token string, ctx context.Context) (authenticationv1.UserInfo, bool, error)
+	// CanPatchNamespace checks if the user may patch the namespace
+	CanPatchNamespace(user authenticationv1.UserInfo, namespace string, ctx context.Context) (bool, error)
+	// CanGetNamespace checks if the user may get the namespace, an empty namespace checks the access to all namespaces
+	CanGetNamespace(user authenticationv1.UserInfo, namespace string, ctx context.Context) (bool, error)
+	// AnnotateNamespace merges the annotations into the annotations of the namespace
+	AnnotateNamespace(namespace string, annotations map[string]string, ctx context.Context) error
 	// ensureSecret ensures that the secret used for storing TLS certificates exists
 	ensureSecret(namespace, secretName string, ctx context.Context) (bool, error)
 	// GetScaledObjects gets all scaledobjects in the specified namespace
 	GetScaledObjects(namespace string, ctx context.Context) ([]scalable.Workload, error)
 	// CreateLease creates a new lease for the downscaler
 	CreateLease(leaseName string) (*resourcelock.LeaseLock, error)
+	// GetConfigMapData gets the data of the configmap, an empty namespace defaults to the downscaler's namespace
+	GetConfigMapData(namespace, name string, ctx context.Context) (map[string]string, error)
+	// SaveConfigMapData creates or updates the configmap with the data, an empty namespace defaults to the downscaler's namespace
+	SaveConfigMapData(namespace, name string, data map[string]string, ctx context.Context) error
 	// GetNamespaceAnnotations gets the annotations of the workload's namespace
 	GetNamespaceAnnotations(namespace string, ctx context.Context) (map[string]string, error)
 	// addEvent creates a new event on either a workload or a namespace
 	addEvent(eventType, reason, identifier, message string, object *corev1.ObjectReference, ctx context.Context) error
 	// GetChildrenWorkloads gets the children workloads of the specified workload
 	GetChildrenWorkloads(workload scalable.Workload, ctx context.Context) ([]scalable.Workload, error)
+	// RedirectRoutes redirects the routes pointing to the workload's services to the maintenance service
+	RedirectRoutes(workload scalable.Workload, service *MaintenanceService, ctx context.Context) error
+	// RestoreRoutes restores the original backends of the routes pointing to the workload's services
+	RestoreRoutes(workload scalable.Workload, ctx context.Context) error
+	// HasPendingRouteUpdate checks if the last redirect or restore of the workload's routes failed
+	HasPendingRouteUpdate(workload scalable.Workload) bool
+	// GetGlobalMode gets the mode of the global switch on the downscaler's namespace
+	GetGlobalMode(ctx context.Context) (values.GlobalMode, error)
+	// AddGlobalModeChangedEvent adds an event announcing the new global mode on the downscaler's namespace
+	AddGlobalModeChangedEvent(mode values.GlobalMode, ctx context.Context) error
+	// IsSafetyLimitOverridden checks if the safety limits are overridden on the downscaler's namespace
+	IsSafetyLimitOverridden(ctx context.Context) (bool, error)
+	// AddSafetyLimitExceededEvent adds an event announcing an aborted scan cycle on the downscaler's namespace
+	AddSafetyLimitExceededEvent(message string, ctx context.Context) error
+	// CheckConnection checks if the Kubernetes API server can be reached and is ready
+	CheckConnection(ctx context.Context) error
 }
 
-// NewClient makes a new Client.
+// NewClient makes a new Client. All mutations of workloads are recorded in the audit log, if it isn't nil.
 //
 // nolint: cyclop // this function is complex due to the multiple clientsets being created.
-func NewClient(kubeconfig string, dryRun bool, qps float64, burst int) (client, error) {
+func NewClient(kubeconfig string, dryRun bool, qps float64, burst int, auditLog *audit.Log) (client, error) {
 	var kubeclient client
 
 	var clientsets scalable.Clientsets
 	var scheme *runtime.Scheme
 
 	kubeclient.dryRun = dryRun
+	kubeclient.auditLog = auditLog
+	kubeclient.driftAnnouncements = newDriftAnnouncements()
+	kubeclient.pendingRouteUpdates = newPendingRouteUpdates()
 
 	config, err := getConfig(kubeconfig)
 	if err != nil {
@@ -162,8 +215,11 @@ func NewScheme() (*runtime.Scheme, error) {
 
 // client is a Kubernetes client with downscaling specific functions.
 type client struct {
-	clientsets *scalable.Clientsets
-	dryRun     bool
+	clientsets          *scalable.Clientsets
+	dryRun              bool
+	auditLog            *audit.Log
+	driftAnnouncements  *driftAnnouncements
+	pendingRouteUpdates *pendingRouteUpdates
 }
 
 // getNamespaceAnnotations gets the annotations of the workload's namespace.
@@ -176,6 +232,16 @@ func (c client) GetNamespaceAnnotations(namespace string, ctx context.Context) (
 	return ns.Annotations, nil
 }
 
+// CheckConnection checks if the Kubernetes API server can be reached and reports itself as ready.
+func (c client) CheckConnection(ctx context.Context) error {
+	_, err := c.clientsets.Kubernetes.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reach the api server: %w", err)
+	}
+
+	return nil
+}
+
 // GetWorkloads gets all workloads of the specified resources for the specified namespaces.
 func (c client) GetWorkloads(
 	namespaces,
@@ -193,26 +259,66 @@ func (c client) GetWorkloads(
 
 	for _, namespace := range namespaces {
 		for _, resourceType := range resourceTypes {
+			if scalable.IsClusterScoped(strings.ToLower(resourceType)) {
+				continue
+			}
+
 			slog.Debug("getting workloads from resource type", "resourceType", resourceType)
 
-			workloads, err := scalable.GetWorkloads(strings.ToLower(resourceType), namespace, c.clientsets, ctx)
+			workloads, err := c.listWorkloads(resourceType, namespace, ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get workloads: %w", err)
+				return nil, err
 			}
 
 			results = append(results, workloads...)
 		}
 	}
 
+	for _, resourceType := range resourceTypes {
+		if !scalable.IsClusterScoped(strings.ToLower(resourceType)) {
+			continue
+		}
+
+		slog.Debug("getting cluster scoped workloads from resource type", "resourceType", resourceType)
+
+		workloads, err := c.listWorkloads(resourceType, "", ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, workloads...)
+	}
+
 	return results, nil
 }
 
+// listWorkloads lists all workloads of the resource type in the namespace, or in all namespaces if it is empty.
+func (c client) listWorkloads(resourceType, namespace string, ctx context.Context) ([]scalable.Workload, error) {
+	ctx, span := tracing.Start(
+		ctx,
+		"list workloads",
+		attribute.String("resourceType", resourceType),
+		attribute.String("namespace", namespace),
+	)
+	defer span.End()
+
+	workloads, err := scalable.GetWorkloads(strings.ToLower(resourceType), namespace, c.clientsets, ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get workloads: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("workloads", len(workloads)))
+
+	return workloads, nil
+}
+
 // GetChildrenWorkloads gets the children workloads of the specified workload.
 func (c client) GetChildrenWorkloads(workload scalable.Workload, ctx context.Context) ([]scalable.Workload, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if parent, ok := workload.(scalable.ParentWorkload); ok {
+	if parent, ok := scalable.GetParentWorkload(workload); ok {
 		slog.Debug(
 			"getting children workloads for workload",
 			"workload", workload.GetName(),
@@ -241,14 +347,44 @@ func (c client) RegetWorkload(workload scalable.Workload, ctx context.Context) e
 	return nil
 }
 
+// GetWorkload gets a single workload of the specified kind.
+func (c client) GetWorkload(kind, namespace, name string, ctx context.Context) (scalable.Workload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// the workload is parsed from its bare metadata, so it can be regotten like any other workload
+	rawObject, err := json.Marshal(metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workload metadata: %w", err)
+	}
+
+	workload, err := scalable.ParseWorkloadFromRawObject(kind, rawObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workload: %w", err)
+	}
+
+	err = workload.Reget(c.clientsets, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload: %w", err)
+	}
+
+	return workload, nil
+}
+
 // DownscaleWorkload downscales the workload to the specified replicas.
 //
 
 func (c client) DownscaleWorkload(
 	replicas values.Replicas,
 	workload scalable.Workload,
+	cause string,
 	ctx context.Context,
 ) (*metrics.SavedResources, error) {
+	original, err := c.copyForAudit(workload)
+	if err != nil {
+		return metrics.NewSavedResources(0, 0), err
+	}
+
 	savedResources, isUpdateNeeded, err := workload.ScaleDown(replicas)
 	if err != nil {
 		return metrics.NewSavedResources(0, 0), fmt.Errorf("failed to set the workload into a scaled down state: %w", err)
@@ -274,23 +410,34 @@ func (c client) DownscaleWorkload(
 		return metrics.NewSavedResources(0, 0), nil
 	}
 
-	err = workload.Update(c.clientsets, ctx)
+	err = c.updateWorkload(workload, original, audit.ActionDownscale, cause, ctx)
 	if err != nil {
-		return metrics.NewSavedResources(0, 0), fmt.Errorf("failed to update the workload: %w", err)
+		return metrics.NewSavedResources(0, 0), err
 	}
 
 	slog.Debug("successfully scaled down workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+	NewResourceLoggerForWorkload(c, workload).InfoDownscaled(replicas, cause, ctx)
 
 	return savedResources, nil
 }
 
 // UpscaleWorkload upscales the workload to the original replicas.
-func (c client) UpscaleWorkload(workload scalable.Workload, ctx context.Context) error {
+func (c client) UpscaleWorkload(workload scalable.Workload, cause string, ctx context.Context) error {
+	originalReplicas, _ := scalable.GetOriginalReplicas(workload)
+
+	original, err := c.copyForAudit(workload)
+	if err != nil {
+		return err
+	}
+
 	isUpdateNeeded, err := workload.ScaleUp()
 	if err != nil {
 		return fmt.Errorf("failed to set the workload into a scaled up state: %w", err)
 	}
 
+	// the drift record is cleared by the upscale, so the announced drift isn't needed anymore
+	c.driftAnnouncements.forget(workload.GetUID())
+
 	if !isUpdateNeeded {
 		slog.Debug(
 			"workload is already in a scaled up state, no update needed",
@@ -311,12 +458,137 @@ func (c client) UpscaleWorkload(workload scalable.Workload, ctx context.Context)
 		return nil
 	}
 
-	err = workload.Update(c.clientsets, ctx)
+	err = c.updateWorkload(workload, original, audit.ActionUpscale, cause, ctx)
 	if err != nil {
-		return fmt.Errorf("failed to update the workload: %w", err)
+		return err
 	}
 
 	slog.Debug("successfully scaled up workload", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+	NewResourceLoggerForWorkload(c, workload).InfoUpscaled(originalReplicas, cause, ctx)
+
+	return nil
+}
+
+// CleanupWorkload upscales the workload and removes all annotations and selectors managed by the downscaler.
+// It returns false if the workload didn't need to be changed.
+func (c client) CleanupWorkload(workload scalable.Workload, ctx context.Context) (bool, error) {
+	original, err := c.copyForAudit(workload)
+	if err != nil {
+		return false, err
+	}
+
+	upscaled, err := workload.ScaleUp()
+	if err != nil {
+		return false, fmt.Errorf("failed to set the workload into a scaled up state: %w", err)
+	}
+
+	cleaned := scalable.RemoveDownscalerState(workload)
+
+	if !upscaled && !cleaned {
+		return false, nil
+	}
+
+	if c.dryRun {
+		slog.Info(
+			"running in dry run mode, would have sent update workload request to clean up workload",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+		)
+
+		return true, nil
+	}
+
+	err = c.updateWorkload(workload, original, audit.ActionCleanup, "", ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RepairDrift detects and repairs manual changes made to the downscaled workload according to the drift policy.
+// The repaired workload is updated and fetched again, so it can be scaled afterwards.
+func (c client) RepairDrift(workload scalable.Workload, policy values.DriftPolicy, ctx context.Context) (*scalable.Drift, error) {
+	original, err := c.copyForAudit(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := scalable.RepairDrift(workload, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repair drift: %w", err)
+	}
+
+	if drift == nil {
+		c.driftAnnouncements.forget(workload.GetUID())
+		return nil, nil //nolint:nilnil // a nil drift means no drift was detected
+	}
+
+	// drift which is left alone is detected again on every scan cycle, but only announced once
+	if c.driftAnnouncements.shouldAnnounce(workload.GetUID(), drift.String()) {
+		slog.Info(
+			"detected manual changes on downscaled workload",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+			"drift", drift.String(),
+			"policy", policy,
+		)
+
+		NewResourceLoggerForWorkload(c, workload).WarnDriftDetected(drift, policy, ctx)
+	}
+
+	if policy == values.DriftPolicyIgnore {
+		return drift, nil
+	}
+
+	if c.dryRun {
+		slog.Info(
+			"running in dry run mode, would have sent update workload request to repair drift",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+		)
+
+		return drift, nil
+	}
+
+	err = c.updateWorkload(workload, original, audit.ActionRepairDrift, drift.String(), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = workload.Reget(c.clientsets, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repaired workload: %w", err)
+	}
+
+	return drift, nil
+}
+
+// copyForAudit copies the workload before it is mutated, so its changes can be recorded in the audit log.
+// It returns nil if the audit log is disabled.
+func (c client) copyForAudit(workload scalable.Workload) (scalable.Workload, error) {
+	if !c.auditLog.Enabled() {
+		return nil, nil //nolint:nilnil // no copy is needed if the audit log is disabled
+	}
+
+	original, err := workload.Copy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy the workload for the audit log: %w", err)
+	}
+
+	return original, nil
+}
+
+// updateWorkload updates the workload and records its changes compared to the original workload in the audit log.
+func (c client) updateWorkload(workload, original scalable.Workload, action, cause string, ctx context.Context) error {
+	err := workload.Update(c.clientsets, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update the workload: %w", err)
+	}
+
+	if original != nil {
+		c.auditLog.RecordWorkloadChange(audit.ActorController, action, original, workload, cause, ctx)
+	}
 
 	return nil
 }
@@ -346,7 +618,13 @@ func (c client) addEvent(
 	hash := sha256.Sum256([]byte(fmt.Sprintf("%s.%s", identifier, message)))
 	name := fmt.Sprintf("%s.%s.%x", object.Name, reason, hash)
 
-	eventsClient := c.clientsets.Kubernetes.CoreV1().Events(object.Namespace)
+	// events of cluster scoped objects are created in the default namespace
+	eventNamespace := object.Namespace
+	if eventNamespace == "" {
+		eventNamespace = metav1.NamespaceDefault
+	}
+
+	eventsClient := c.clientsets.Kubernetes.CoreV1().Events(eventNamespace)
 
 	if event, err := eventsClient.Get(ctx, name, metav1.GetOptions{}); err == nil && event != nil {
 		event.Count++
@@ -363,7 +641,7 @@ func (c client) addEvent(
 	_, err := eventsClient.Create(ctx, &corev1.Event{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: object.Namespace,
+			Namespace: eventNamespace,
 		},
 		InvolvedObject: *object,
 		Reason:         reason,
@@ -424,6 +702,9 @@ func (c client) GetNamespacesAsSet() (map[string]struct{}, error) {
 
 // GetNamespacesScopes gets the namespaces scopes from the namespaces annotations.
 func (c client) GetNamespacesScopes(workloads []scalable.Workload, ctx context.Context) (map[string]*values.Scope, error) {
+	ctx, span := tracing.Start(ctx, "get namespace scopes")
+	defer span.End()
+
 	var waitGroup sync.WaitGroup
 
 	namespaceSet := make(map[string]struct{})
@@ -436,6 +717,8 @@ func (c client) GetNamespacesScopes(workloads []scalable.Workload, ctx context.C
 		}
 	}
 
+	span.SetAttributes(attribute.Int("namespaces", len(namespaceSet)))
+
 	namespaceScopes := make(map[string]*values.Scope, len(namespaceSet))
 	errChan := make(chan error, len(namespaceSet))
 	resultChan := make(chan map[string]*values.Scope, len(namespaceSet))
@@ -466,6 +749,7 @@ func (c client) GetNamespacesScopes(workloads []scalable.Workload, ctx context.C
 
 	for err := range errChan {
 		if err != nil {
+			tracing.RecordError(span, err)
 			return nil, err
 		}
 	}
@@ -480,6 +764,14 @@ func (c client) GetNamespacesScopes(workloads []scalable.Workload, ctx context.C
 }
 
 func (c client) GetNamespaceScope(namespace string, ctx context.Context) (*values.Scope, error) {
+	ctx, span := tracing.Start(ctx, "get namespace scope", attribute.String("namespace", namespace))
+	defer span.End()
+
+	if namespace == "" {
+		slog.Debug("cluster scoped workloads don't have a namespace, using an empty namespace scope")
+		return values.NewScope(), nil
+	}
+
 	nsLogger := NewResourceLoggerForNamespace(c, namespace)
 
 	slog.Debug("fetching namespace annotations", "namespace", namespace)
@@ -487,6 +779,8 @@ func (c client) GetNamespaceScope(namespace string, ctx context.Context) (*value
 	annotations, err := c.GetNamespaceAnnotations(namespace, ctx)
 	if err != nil {
 		err = fmt.Errorf("failed to get namespace annotations for namespace %s: %w", namespace, err)
+		tracing.RecordError(span, err)
+
 		return nil, err
 	}
 
@@ -497,6 +791,8 @@ func (c client) GetNamespaceScope(namespace string, ctx context.Context) (*value
 	err = namespaceScope.GetScopeFromAnnotations(annotations, nsLogger, ctx)
 	if err != nil {
 		err = fmt.Errorf("failed to parse scope from annotations for namespace %s: %w", namespace, err)
+		tracing.RecordError(span, err)
+
 		return nil, err
 	}
 
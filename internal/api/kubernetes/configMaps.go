@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resolveConfigMapNamespace defaults an empty namespace to the downscaler's namespace.
+func resolveConfigMapNamespace(namespace string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+
+	controlNamespace, ok := getControlNamespace()
+	if !ok {
+		return "", ErrUnknownNamespace
+	}
+
+	return controlNamespace, nil
+}
+
+// GetConfigMapData gets the data of the configmap. An empty namespace defaults to the downscaler's namespace.
+func (c client) GetConfigMapData(namespace, name string, ctx context.Context) (map[string]string, error) {
+	namespace, err := resolveConfigMapNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := c.clientsets.Kubernetes.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap: %w", err)
+	}
+
+	return configMap.Data, nil
+}
+
+// SaveConfigMapData creates the configmap or replaces the data of the existing configmap.
+// An empty namespace defaults to the downscaler's namespace.
+func (c client) SaveConfigMapData(namespace, name string, data map[string]string, ctx context.Context) error {
+	namespace, err := resolveConfigMapNamespace(namespace)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have saved configmap", "name", name, "namespace", namespace)
+		return nil
+	}
+
+	configMaps := c.clientsets.Kubernetes.CoreV1().ConfigMaps(namespace)
+
+	configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create configmap: %w", err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get configmap: %w", err)
+	}
+
+	configMap.Data = data
+
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update configmap: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationGlobalMode           = "downscaler/global-mode"
+	annotationOverrideSafetyLimits = "downscaler/override-safety-limits"
+	reasonGlobalModeChanged        = "GlobalModeChanged"
+	reasonSafetyLimitExceeded      = "SafetyLimitExceeded"
+)
+
+// IsControlAnnotation checks if the annotation is one of the annotations controlling the whole downscaler.
+func IsControlAnnotation(annotation string) bool {
+	return annotation == annotationGlobalMode || annotation == annotationOverrideSafetyLimits
+}
+
+// getControlNamespace gets the namespace the downscaler is running in, which holds the annotations controlling the whole downscaler.
+// It returns false if the namespace can't be determined, e.g. when running outside of the cluster.
+func getControlNamespace() (string, bool) {
+	namespace, err := getCurrentNamespace()
+	if err != nil {
+		slog.Debug("downscaler namespace can't be determined, controls on the namespace are not available", "error", err)
+		return "", false
+	}
+
+	return namespace, true
+}
+
+// GetGlobalMode gets the mode of the global switch from the annotations of the downscaler's namespace.
+// An invalid mode is reported on the namespace and treated as pause, so a typo during an incident still freezes all activity.
+func (c client) GetGlobalMode(ctx context.Context) (values.GlobalMode, error) {
+	namespace, ok := getControlNamespace()
+	if !ok {
+		return values.GlobalModeResume, nil
+	}
+
+	annotations, err := c.GetNamespaceAnnotations(namespace, ctx)
+	if err != nil {
+		return values.GlobalModeResume, fmt.Errorf("failed to get annotations of the downscaler namespace: %w", err)
+	}
+
+	mode, err := values.ParseGlobalMode(annotations[annotationGlobalMode])
+	if err != nil {
+		err = fmt.Errorf("failed to parse %q annotation: %w", annotationGlobalMode, err)
+		NewResourceLoggerForNamespace(c, namespace).ErrorInvalidAnnotation(annotationGlobalMode, err.Error(), ctx)
+		slog.Error("invalid global mode, pausing the downscaler", "error", err, "namespace", namespace)
+	}
+
+	return mode, nil
+}
+
+// IsSafetyLimitOverridden checks if the safety limits are overridden by an annotation on the downscaler's namespace.
+func (c client) IsSafetyLimitOverridden(ctx context.Context) (bool, error) {
+	namespace, ok := getControlNamespace()
+	if !ok {
+		return false, nil
+	}
+
+	annotations, err := c.GetNamespaceAnnotations(namespace, ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get annotations of the downscaler namespace: %w", err)
+	}
+
+	override, ok := annotations[annotationOverrideSafetyLimits]
+	if !ok {
+		return false, nil
+	}
+
+	overridden, err := strconv.ParseBool(override)
+	if err != nil {
+		err = fmt.Errorf("failed to parse %q annotation: %w", annotationOverrideSafetyLimits, err)
+		NewResourceLoggerForNamespace(c, namespace).ErrorInvalidAnnotation(annotationOverrideSafetyLimits, err.Error(), ctx)
+
+		return false, err
+	}
+
+	return overridden, nil
+}
+
+// AddGlobalModeChangedEvent adds an event to the downscaler's namespace announcing the new mode of the global switch.
+func (c client) AddGlobalModeChangedEvent(mode values.GlobalMode, ctx context.Context) error {
+	return c.addControlNamespaceEvent(
+		corev1.EventTypeNormal,
+		reasonGlobalModeChanged,
+		annotationGlobalMode,
+		fmt.Sprintf("the global mode of the downscaler changed to %q", mode),
+		ctx,
+	)
+}
+
+// AddSafetyLimitExceededEvent adds a warning to the downscaler's namespace announcing that a scan cycle was aborted.
+func (c client) AddSafetyLimitExceededEvent(message string, ctx context.Context) error {
+	return c.addControlNamespaceEvent(corev1.EventTypeWarning, reasonSafetyLimitExceeded, annotationOverrideSafetyLimits, message, ctx)
+}
+
+// addControlNamespaceEvent adds an event to the downscaler's namespace.
+func (c client) addControlNamespaceEvent(eventType, reason, identifier, message string, ctx context.Context) error {
+	namespace, ok := getControlNamespace()
+	if !ok {
+		return nil
+	}
+
+	involvedObject := corev1.ObjectReference{
+		Kind:       "Namespace",
+		Name:       namespace,
+		APIVersion: "v1",
+	}
+
+	err := c.addEvent(eventType, reason, identifier, message, &involvedObject, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add %s event: %w", reason, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// driftAnnouncements keeps track of the drift already announced for each workload,
+// so drift which is left alone isn't announced again on every scan cycle. It is safe for concurrent use.
+type driftAnnouncements struct {
+	mutex     sync.Mutex
+	announced map[types.UID]string
+}
+
+// newDriftAnnouncements creates a new empty driftAnnouncements.
+func newDriftAnnouncements() *driftAnnouncements {
+	return &driftAnnouncements{announced: make(map[types.UID]string)}
+}
+
+// shouldAnnounce checks if the drift of the workload changed since it was last announced and remembers it.
+func (d *driftAnnouncements) shouldAnnounce(uid types.UID, drift string) bool {
+	if d == nil {
+		return true
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.announced[uid] == drift {
+		return false
+	}
+
+	d.announced[uid] = drift
+
+	return true
+}
+
+// forget removes the announced drift of the workload, so a new drift is announced again.
+func (d *driftAnnouncements) forget(uid types.UID) {
+	if d == nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	delete(d.announced, uid)
+}
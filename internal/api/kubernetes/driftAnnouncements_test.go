@@ -0,0 +1,40 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftAnnouncements(t *testing.T) {
+	t.Parallel()
+
+	announcements := newDriftAnnouncements()
+
+	assert.True(t, announcements.shouldAnnounce("uid", "changed from 0 to 3"), "new drift should be announced")
+	assert.False(t, announcements.shouldAnnounce("uid", "changed from 0 to 3"), "unchanged drift shouldn't be announced again")
+	assert.True(t, announcements.shouldAnnounce("uid", "changed from 0 to 5"), "changed drift should be announced")
+	assert.True(t, announcements.shouldAnnounce("other", "changed from 0 to 5"), "drift of other workloads should be announced")
+
+	announcements.forget("uid")
+	assert.True(t, announcements.shouldAnnounce("uid", "changed from 0 to 5"), "drift should be announced again after it was resolved")
+}
+
+func TestUpscaleWorkloadForgetsDriftAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	kubeclient := client{dryRun: true, driftAnnouncements: newDriftAnnouncements()}
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(`{
+		"metadata": {"name": "web", "namespace": "default", "uid": "web-uid", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 5}
+	}`))
+	require.NoError(t, err)
+
+	assert.True(t, kubeclient.driftAnnouncements.shouldAnnounce(workload.GetUID(), "changed from 0 to 5"))
+
+	require.NoError(t, kubeclient.UpscaleWorkload(workload, "", t.Context()))
+	assert.Empty(t, kubeclient.driftAnnouncements.announced, "upscaled workloads shouldn't keep their announced drift")
+}
@@ -2,13 +2,24 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	v1 "k8s.io/api/core/v1"
 )
 
-const reasonInvalidConfiguration = "InvalidConfiguration"
+const (
+	reasonInvalidConfiguration = "InvalidConfiguration"
+	reasonDriftDetected        = "DriftDetected"
+	reasonDownscaled           = "Downscaled"
+	reasonUpscaled             = "Upscaled"
+	reasonExcluded             = "Excluded"
+	reasonGracePeriodExpired   = "GracePeriodExpired"
+	reasonWakeRequested        = "WakeRequested"
+)
 
 // Logger handles logging for both namespaces and workloads.
 type ResourceLogger struct {
@@ -51,6 +62,88 @@ func (r ResourceLogger) ErrorIncompatibleFields(message string, ctx context.Cont
 	}
 }
 
+// WarnDriftDetected adds an event explaining the manual changes made to the target and the action taken by the downscaler.
+func (r ResourceLogger) WarnDriftDetected(drift *scalable.Drift, policy values.DriftPolicy, ctx context.Context) {
+	actions := map[values.DriftPolicy]string{
+		values.DriftPolicyRedownscale: "the workload was downscaled again and will be upscaled to its original replicas",
+		values.DriftPolicyAdopt:       "the manual changes were adopted as the new original replicas and the workload was downscaled again",
+		values.DriftPolicyIgnore:      "the workload is left alone until it is upscaled",
+	}
+
+	action := actions[policy]
+	if policy != values.DriftPolicyIgnore && drift.CurrentReplicas == drift.DownscaledReplicas {
+		action = "the annotation was restored from the downscale record"
+	}
+
+	message := fmt.Sprintf("%s, %s (drift policy %q)", drift.String(), action, policy)
+
+	err := r.logger.log(v1.EventTypeWarning, reasonDriftDetected, reasonDriftDetected, message, ctx)
+	if err != nil {
+		slog.Error("failed to add drift event", "error", err)
+	}
+}
+
+// InfoDownscaled adds an event announcing that the target was downscaled to the replicas.
+func (r ResourceLogger) InfoDownscaled(replicas values.Replicas, cause string, ctx context.Context) {
+	message := fmt.Sprintf("downscaled to %s replicas", replicas)
+	if cause != "" {
+		message += ", " + cause
+	}
+
+	err := r.logger.log(v1.EventTypeNormal, reasonDownscaled, reasonDownscaled, message, ctx)
+	if err != nil {
+		slog.Error("failed to add downscale event", "error", err)
+	}
+}
+
+// InfoUpscaled adds an event announcing that the target was upscaled to its original replicas.
+// If the original replicas are unknown (e.g. for suspended workloads), the event only states that the original state was restored.
+func (r ResourceLogger) InfoUpscaled(originalReplicas, cause string, ctx context.Context) {
+	message := "upscaled to its original state"
+	if originalReplicas != "" {
+		message = fmt.Sprintf("upscaled to %s replicas", originalReplicas)
+	}
+
+	if cause != "" {
+		message += ", " + cause
+	}
+
+	err := r.logger.log(v1.EventTypeNormal, reasonUpscaled, reasonUpscaled, message, ctx)
+	if err != nil {
+		slog.Error("failed to add upscale event", "error", err)
+	}
+}
+
+// InfoExcluded adds an event announcing that the target became excluded from scaling.
+func (r ResourceLogger) InfoExcluded(ctx context.Context) {
+	message := "the workload is excluded from scaling and is left in its current state"
+
+	err := r.logger.log(v1.EventTypeNormal, reasonExcluded, reasonExcluded, message, ctx)
+	if err != nil {
+		slog.Error("failed to add exclusion event", "error", err)
+	}
+}
+
+// InfoGracePeriodExpired adds an event announcing that the grace period of the target expired.
+func (r ResourceLogger) InfoGracePeriodExpired(ctx context.Context) {
+	message := "the grace period of the workload expired, it is scaled from now on"
+
+	err := r.logger.log(v1.EventTypeNormal, reasonGracePeriodExpired, reasonGracePeriodExpired, message, ctx)
+	if err != nil {
+		slog.Error("failed to add grace period event", "error", err)
+	}
+}
+
+// InfoWakeRequested adds an event announcing that a wake request was accepted and until when the target is kept upscaled.
+func (r ResourceLogger) InfoWakeRequested(wakeUntil time.Time, ctx context.Context) {
+	message := fmt.Sprintf("a wake up was requested, scaling up until %s", wakeUntil.UTC().Format(time.RFC3339))
+
+	err := r.logger.log(v1.EventTypeNormal, reasonWakeRequested, reasonWakeRequested, message, ctx)
+	if err != nil {
+		slog.Error("failed to add wake request event", "error", err)
+	}
+}
+
 // resourceLogger is the interface that all loggers (namespace and workload) implement.
 type resourceLogger interface {
 	log(eventType, reason, identifier, message string, ctx context.Context) error
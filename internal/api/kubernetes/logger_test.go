@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+type loggedEvent struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+// recordingLogger is a resourceLogger which records the logged events instead of creating them.
+type recordingLogger struct {
+	events []loggedEvent
+}
+
+func (r *recordingLogger) log(eventType, reason, _, message string, _ context.Context) error {
+	r.events = append(r.events, loggedEvent{eventType: eventType, reason: reason, message: message})
+	return nil
+}
+
+func TestScalingEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		logEvent  func(logger ResourceLogger, ctx context.Context)
+		wantEvent loggedEvent
+	}{
+		{
+			name: "downscaled",
+			logEvent: func(logger ResourceLogger, ctx context.Context) {
+				logger.InfoDownscaled(values.AbsoluteReplicas(0), "decided by ScopeNamespace", ctx)
+			},
+			wantEvent: loggedEvent{v1.EventTypeNormal, reasonDownscaled, "downscaled to 0 replicas, decided by ScopeNamespace"},
+		},
+		{
+			name: "upscaled to original replicas",
+			logEvent: func(logger ResourceLogger, ctx context.Context) {
+				logger.InfoUpscaled("3", "decided by ScopeWorkload", ctx)
+			},
+			wantEvent: loggedEvent{v1.EventTypeNormal, reasonUpscaled, "upscaled to 3 replicas, decided by ScopeWorkload"},
+		},
+		{
+			name: "upscaled without known replicas or cause",
+			logEvent: func(logger ResourceLogger, ctx context.Context) {
+				logger.InfoUpscaled("", "", ctx)
+			},
+			wantEvent: loggedEvent{v1.EventTypeNormal, reasonUpscaled, "upscaled to its original state"},
+		},
+		{
+			name: "grace period expired",
+			logEvent: func(logger ResourceLogger, ctx context.Context) {
+				logger.InfoGracePeriodExpired(ctx)
+			},
+			wantEvent: loggedEvent{
+				v1.EventTypeNormal, reasonGracePeriodExpired, "the grace period of the workload expired, it is scaled from now on",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingLogger{}
+			test.logEvent(ResourceLogger{logger: recorder}, t.Context())
+
+			assert.Equal(t, []loggedEvent{test.wantEvent}, recorder.events)
+		})
+	}
+}
@@ -0,0 +1,564 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	annotationOriginalBackends = "downscaler/original-backends"
+	ingressDefaultBackendKey   = "defaultBackend"
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var httpRouteGVK = schema.GroupVersionKind{
+	Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute",
+}
+
+// MaintenanceService is the service routes are redirected to while their workloads are downscaled.
+type MaintenanceService struct {
+	// Namespace is the namespace of the service. Empty means the namespace of the route.
+	Namespace string
+	// Name is the name of the service.
+	Name string
+	// Port is the port of the service.
+	Port int32
+}
+
+// NewMaintenanceService creates a MaintenanceService from a service in the format [namespace/]name.
+// It returns nil if the service is empty, which disables maintenance redirects.
+func NewMaintenanceService(service string, port int32) *MaintenanceService {
+	if service == "" {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(service, "/")
+	if !found {
+		return &MaintenanceService{Name: service, Port: port}
+	}
+
+	return &MaintenanceService{Namespace: namespace, Name: name, Port: port}
+}
+
+// isReachableFrom checks if the service can be referenced by a route in the given namespace.
+func (m *MaintenanceService) isReachableFrom(namespace string) bool {
+	return m.Namespace == "" || m.Namespace == namespace
+}
+
+// RedirectRoutes redirects the Ingress and HTTPRoute backends pointing to the workload's services to the maintenance service.
+// A failed redirect is remembered, so it is retried in the next scan cycle.
+func (c client) RedirectRoutes(workload scalable.Workload, service *MaintenanceService, ctx context.Context) error {
+	err := c.redirectRoutes(workload, service, ctx)
+	c.pendingRouteUpdates.track(workload.GetUID(), err)
+
+	return err
+}
+
+// RestoreRoutes restores the original Ingress and HTTPRoute backends pointing to the workload's services.
+// A failed restore is remembered, so it is retried in the next scan cycle.
+func (c client) RestoreRoutes(workload scalable.Workload, ctx context.Context) error {
+	err := c.restoreRoutes(workload, ctx)
+	c.pendingRouteUpdates.track(workload.GetUID(), err)
+
+	return err
+}
+
+// HasPendingRouteUpdate checks if the last redirect or restore of the workload's routes failed.
+func (c client) HasPendingRouteUpdate(workload scalable.Workload) bool {
+	return c.pendingRouteUpdates.has(workload.GetUID())
+}
+
+// redirectRoutes redirects the Ingress and HTTPRoute backends pointing to the workload's services to the maintenance service.
+func (c client) redirectRoutes(workload scalable.Workload, service *MaintenanceService, ctx context.Context) error {
+	// neither Ingresses nor HTTPRoutes without a ReferenceGrant may reference services in other namespaces
+	if !service.isReachableFrom(workload.GetNamespace()) {
+		slog.Warn(
+			"maintenance service is in a different namespace, skipping redirect of routes",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+		)
+
+		return nil
+	}
+
+	serviceNames, err := c.getWorkloadServiceNames(workload, ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(serviceNames) == 0 {
+		return nil
+	}
+
+	err = c.updateIngresses(workload.GetNamespace(), ctx, func(ingress *networkingv1.Ingress) (bool, error) {
+		return redirectIngress(ingress, serviceNames, service)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to redirect ingresses: %w", err)
+	}
+
+	err = c.updateHTTPRoutes(workload.GetNamespace(), ctx, func(route *unstructured.Unstructured) (bool, error) {
+		return redirectHTTPRoute(route, serviceNames, service)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to redirect httproutes: %w", err)
+	}
+
+	return nil
+}
+
+// restoreRoutes restores the original Ingress and HTTPRoute backends pointing to the workload's services.
+func (c client) restoreRoutes(workload scalable.Workload, ctx context.Context) error {
+	serviceNames, err := c.getWorkloadServiceNames(workload, ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(serviceNames) == 0 {
+		return nil
+	}
+
+	err = c.updateIngresses(workload.GetNamespace(), ctx, func(ingress *networkingv1.Ingress) (bool, error) {
+		return restoreIngress(ingress, serviceNames)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore ingresses: %w", err)
+	}
+
+	err = c.updateHTTPRoutes(workload.GetNamespace(), ctx, func(route *unstructured.Unstructured) (bool, error) {
+		return restoreHTTPRoute(route, serviceNames)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore httproutes: %w", err)
+	}
+
+	return nil
+}
+
+// getWorkloadServiceNames gets the names of the services selecting the pods of the workload.
+func (c client) getWorkloadServiceNames(workload scalable.Workload, ctx context.Context) ([]string, error) {
+	podLabels := scalable.GetPodTemplateLabels(workload)
+	if podLabels == nil {
+		return nil, nil
+	}
+
+	services, err := c.clientsets.Kubernetes.CoreV1().Services(workload.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	return getSelectingServiceNames(services.Items, podLabels), nil
+}
+
+// getSelectingServiceNames gets the names of the services which select pods with the given labels.
+func getSelectingServiceNames(services []corev1.Service, podLabels map[string]string) []string {
+	serviceNames := make([]string, 0, len(services))
+
+	for i := range services {
+		selector := services[i].Spec.Selector
+		if len(selector) == 0 {
+			continue
+		}
+
+		if !labels.SelectorFromSet(selector).Matches(labels.Set(podLabels)) {
+			continue
+		}
+
+		serviceNames = append(serviceNames, services[i].Name)
+	}
+
+	return serviceNames
+}
+
+// updateIngresses applies the mutation to all ingresses in the namespace and updates the changed ones.
+func (c client) updateIngresses(
+	namespace string,
+	ctx context.Context,
+	mutate func(ingress *networkingv1.Ingress) (bool, error),
+) error {
+	ingresses, err := c.clientsets.Kubernetes.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get ingresses: %w", err)
+	}
+
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return c.updateIngress(ingress, mutate, ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update ingress %s: %w", ingress.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// updateIngress applies the mutation to the ingress and updates it if it changed.
+// On a conflict the ingress is regot, so the mutation is applied to its latest version when retrying.
+func (c client) updateIngress(
+	ingress *networkingv1.Ingress,
+	mutate func(ingress *networkingv1.Ingress) (bool, error),
+	ctx context.Context,
+) error {
+	changed, err := mutate(ingress)
+	if err != nil {
+		return fmt.Errorf("failed to update backends: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have updated the backends of ingress", "ingress", ingress.Name, "namespace", ingress.Namespace)
+		return nil
+	}
+
+	ingresses := c.clientsets.Kubernetes.NetworkingV1().Ingresses(ingress.Namespace)
+
+	_, err = ingresses.Update(ctx, ingress, metav1.UpdateOptions{})
+	if errors.IsConflict(err) {
+		latest, getErr := ingresses.Get(ctx, ingress.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return fmt.Errorf("failed to reget ingress: %w", getErr)
+		}
+
+		*ingress = *latest
+	}
+
+	return err //nolint:wrapcheck // the conflict error needs to stay detectable for the retry
+}
+
+// updateHTTPRoutes applies the mutation to all httproutes in the namespace and updates the changed ones.
+func (c client) updateHTTPRoutes(
+	namespace string,
+	ctx context.Context,
+	mutate func(route *unstructured.Unstructured) (bool, error),
+) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(httpRouteGVK.GroupVersion().WithKind(httpRouteGVK.Kind + "List"))
+
+	err := c.clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace))
+	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Debug("gateway api CRD not found in cluster, skipping httproutes", "error", err)
+			return nil
+		}
+
+		return fmt.Errorf("failed to get httproutes: %w", err)
+	}
+
+	for i := range list.Items {
+		route := &list.Items[i]
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return c.updateHTTPRoute(route, mutate, ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update httproute %s: %w", route.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// updateHTTPRoute applies the mutation to the httproute and updates it if it changed.
+// On a conflict the httproute is regot, so the mutation is applied to its latest version when retrying.
+func (c client) updateHTTPRoute(
+	route *unstructured.Unstructured,
+	mutate func(route *unstructured.Unstructured) (bool, error),
+	ctx context.Context,
+) error {
+	changed, err := mutate(route)
+	if err != nil {
+		return fmt.Errorf("failed to update backends: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if c.dryRun {
+		slog.Info(
+			"running in dry run mode, would have updated the backends of httproute",
+			"httproute", route.GetName(),
+			"namespace", route.GetNamespace(),
+		)
+
+		return nil
+	}
+
+	err = c.clientsets.Client.Update(ctx, route)
+	if errors.IsConflict(err) {
+		getErr := c.clientsets.Client.Get(ctx, ctrlclient.ObjectKeyFromObject(route), route)
+		if getErr != nil {
+			return fmt.Errorf("failed to reget httproute: %w", getErr)
+		}
+	}
+
+	return err //nolint:wrapcheck // the conflict error needs to stay detectable for the retry
+}
+
+// getOriginalBackends gets the original backends stored in the annotations, mapped by the backends position.
+func getOriginalBackends[T any](annotations map[string]string) (map[string]T, error) {
+	originalBackends := make(map[string]T)
+
+	originalBackendsString, ok := annotations[annotationOriginalBackends]
+	if !ok {
+		return originalBackends, nil
+	}
+
+	err := json.Unmarshal([]byte(originalBackendsString), &originalBackends)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse original backends annotation: %w", err)
+	}
+
+	return originalBackends, nil
+}
+
+// setOriginalBackends stores the original backends in the annotations of the object. The annotation is removed if there are none.
+func setOriginalBackends[T any](object metav1.Object, originalBackends map[string]T) error {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if len(originalBackends) == 0 {
+		delete(annotations, annotationOriginalBackends)
+		object.SetAnnotations(annotations)
+
+		return nil
+	}
+
+	originalBackendsString, err := json.Marshal(originalBackends)
+	if err != nil {
+		return fmt.Errorf("failed to marshal original backends: %w", err)
+	}
+
+	annotations[annotationOriginalBackends] = string(originalBackendsString)
+	object.SetAnnotations(annotations)
+
+	return nil
+}
+
+// getIngressBackends gets all backends of the ingress, mapped by their position.
+func getIngressBackends(ingress *networkingv1.Ingress) map[string]*networkingv1.IngressBackend {
+	backends := make(map[string]*networkingv1.IngressBackend)
+
+	if ingress.Spec.DefaultBackend != nil {
+		backends[ingressDefaultBackendKey] = ingress.Spec.DefaultBackend
+	}
+
+	for ruleIndex := range ingress.Spec.Rules {
+		http := ingress.Spec.Rules[ruleIndex].HTTP
+		if http == nil {
+			continue
+		}
+
+		for pathIndex := range http.Paths {
+			backends[fmt.Sprintf("rules/%d/paths/%d", ruleIndex, pathIndex)] = &http.Paths[pathIndex].Backend
+		}
+	}
+
+	return backends
+}
+
+// redirectIngress redirects the backends of the ingress pointing to one of the services to the maintenance service.
+func redirectIngress(ingress *networkingv1.Ingress, serviceNames []string, service *MaintenanceService) (bool, error) {
+	originalBackends, err := getOriginalBackends[networkingv1.IngressBackend](ingress.Annotations)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+
+	for key, backend := range getIngressBackends(ingress) {
+		if backend.Service == nil || !slices.Contains(serviceNames, backend.Service.Name) {
+			continue
+		}
+
+		originalBackends[key] = *backend.DeepCopy()
+		*backend = networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: service.Name,
+			Port: networkingv1.ServiceBackendPort{Number: service.Port},
+		}}
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, setOriginalBackends(ingress, originalBackends)
+}
+
+// restoreIngress restores the original backends of the ingress which pointed to one of the services.
+func restoreIngress(ingress *networkingv1.Ingress, serviceNames []string) (bool, error) {
+	originalBackends, err := getOriginalBackends[networkingv1.IngressBackend](ingress.Annotations)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	backends := getIngressBackends(ingress)
+
+	for key, originalBackend := range originalBackends {
+		if originalBackend.Service == nil || !slices.Contains(serviceNames, originalBackend.Service.Name) {
+			continue
+		}
+
+		if backend, ok := backends[key]; ok {
+			*backend = originalBackend
+		}
+
+		delete(originalBackends, key)
+
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, setOriginalBackends(ingress, originalBackends)
+}
+
+// isHTTPRouteServiceBackend checks if the backendRef of the httproute points to one of the services.
+func isHTTPRouteServiceBackend(backendRef map[string]any, namespace string, serviceNames []string) bool {
+	group, _, _ := unstructured.NestedString(backendRef, "group")
+	kind, _, _ := unstructured.NestedString(backendRef, "kind")
+	backendNamespace, _, _ := unstructured.NestedString(backendRef, "namespace")
+	name, _, _ := unstructured.NestedString(backendRef, "name")
+
+	if group != "" || (kind != "" && kind != "Service") {
+		return false
+	}
+
+	if backendNamespace != "" && backendNamespace != namespace {
+		return false
+	}
+
+	return slices.Contains(serviceNames, name)
+}
+
+// redirectHTTPRoute redirects the backendRefs of the httproute pointing to one of the services to the maintenance service.
+func redirectHTTPRoute(route *unstructured.Unstructured, serviceNames []string, service *MaintenanceService) (bool, error) {
+	originalBackends, err := getOriginalBackends[map[string]any](route.GetAnnotations())
+	if err != nil {
+		return false, err
+	}
+
+	rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if err != nil {
+		return false, fmt.Errorf("failed to get spec.rules: %w", err)
+	}
+
+	changed := false
+
+	for ruleIndex, rule := range rules {
+		ruleObject, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		backendRefs, _, _ := unstructured.NestedSlice(ruleObject, "backendRefs")
+
+		for backendIndex, backendRef := range backendRefs {
+			backendRefObject, ok := backendRef.(map[string]any)
+			if !ok || !isHTTPRouteServiceBackend(backendRefObject, route.GetNamespace(), serviceNames) {
+				continue
+			}
+
+			originalBackends[fmt.Sprintf("rules/%d/backendRefs/%d", ruleIndex, backendIndex)] = backendRefObject
+
+			redirectedBackendRef := map[string]any{"name": service.Name, "port": int64(service.Port)}
+
+			if weight, ok := backendRefObject["weight"]; ok {
+				redirectedBackendRef["weight"] = weight
+			}
+
+			backendRefs[backendIndex] = redirectedBackendRef
+			changed = true
+		}
+
+		ruleObject["backendRefs"] = backendRefs
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	err = unstructured.SetNestedSlice(route.Object, rules, "spec", "rules")
+	if err != nil {
+		return false, fmt.Errorf("failed to set spec.rules: %w", err)
+	}
+
+	return true, setOriginalBackends(route, originalBackends)
+}
+
+// restoreHTTPRoute restores the original backendRefs of the httproute which pointed to one of the services.
+func restoreHTTPRoute(route *unstructured.Unstructured, serviceNames []string) (bool, error) {
+	originalBackends, err := getOriginalBackends[map[string]any](route.GetAnnotations())
+	if err != nil {
+		return false, err
+	}
+
+	rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if err != nil {
+		return false, fmt.Errorf("failed to get spec.rules: %w", err)
+	}
+
+	changed := false
+
+	for key, originalBackend := range originalBackends {
+		if !isHTTPRouteServiceBackend(originalBackend, route.GetNamespace(), serviceNames) {
+			continue
+		}
+
+		var ruleIndex, backendIndex int
+
+		_, err = fmt.Sscanf(key, "rules/%d/backendRefs/%d", &ruleIndex, &backendIndex)
+		if err == nil && ruleIndex < len(rules) {
+			if ruleObject, ok := rules[ruleIndex].(map[string]any); ok {
+				backendRefs, _, _ := unstructured.NestedSlice(ruleObject, "backendRefs")
+				if backendIndex < len(backendRefs) {
+					backendRefs[backendIndex] = originalBackend
+					ruleObject["backendRefs"] = backendRefs
+				}
+			}
+		}
+
+		delete(originalBackends, key)
+
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	err = unstructured.SetNestedSlice(route.Object, rules, "spec", "rules")
+	if err != nil {
+		return false, fmt.Errorf("failed to set spec.rules: %w", err)
+	}
+
+	return true, setOriginalBackends(route, originalBackends)
+}
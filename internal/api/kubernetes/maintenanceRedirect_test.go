@@ -0,0 +1,271 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewMaintenanceService(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    *MaintenanceService
+	}{
+		{
+			name:    "disabled",
+			service: "",
+			want:    nil,
+		},
+		{
+			name:    "same namespace",
+			service: "sleeping",
+			want:    &MaintenanceService{Name: "sleeping", Port: 80},
+		},
+		{
+			name:    "other namespace",
+			service: "maintenance/sleeping",
+			want:    &MaintenanceService{Namespace: "maintenance", Name: "sleeping", Port: 80},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, NewMaintenanceService(test.service, 80))
+		})
+	}
+}
+
+func TestGetSelectingServiceNames(t *testing.T) {
+	t.Parallel()
+
+	services := []corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "matching"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "api"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "headless"}},
+	}
+
+	got := getSelectingServiceNames(services, map[string]string{"app": "web", "tier": "frontend"})
+
+	assert.Equal(t, []string{"matching"}, got)
+}
+
+func TestRedirectRestoreIngress(t *testing.T) {
+	t.Parallel()
+
+	webBackend := networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+		Name: "web",
+		Port: networkingv1.ServiceBackendPort{Name: "http"},
+	}}
+	apiBackend := networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+		Name: "api",
+		Port: networkingv1.ServiceBackendPort{Number: 8080},
+	}}
+
+	ingress := &networkingv1.Ingress{Spec: networkingv1.IngressSpec{
+		DefaultBackend: webBackend.DeepCopy(),
+		Rules: []networkingv1.IngressRule{{
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/", Backend: *webBackend.DeepCopy()},
+					{Path: "/api", Backend: *apiBackend.DeepCopy()},
+				},
+			}},
+		}},
+	}}
+
+	changed, err := redirectIngress(ingress, []string{"web"}, &MaintenanceService{Name: "sleeping", Port: 80})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "sleeping", ingress.Spec.DefaultBackend.Service.Name)
+	assert.Equal(t, "sleeping", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, apiBackend, ingress.Spec.Rules[0].HTTP.Paths[1].Backend)
+	assert.Contains(t, ingress.Annotations, annotationOriginalBackends)
+
+	changed, err = restoreIngress(ingress, []string{"web"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, webBackend, *ingress.Spec.DefaultBackend)
+	assert.Equal(t, webBackend, ingress.Spec.Rules[0].HTTP.Paths[0].Backend)
+	assert.NotContains(t, ingress.Annotations, annotationOriginalBackends)
+
+	changed, err = restoreIngress(ingress, []string{"web"})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestRedirectRestoreHTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	originalRules := []any{
+		map[string]any{
+			"backendRefs": []any{
+				map[string]any{"name": "web", "port": int64(8080), "weight": int64(90)},
+				map[string]any{"name": "web", "kind": "ServiceImport", "port": int64(8080)},
+			},
+		},
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "route", "namespace": "default"},
+		"spec":     map[string]any{"rules": originalRules},
+	}}
+	route.SetGroupVersionKind(httpRouteGVK)
+
+	expectedRules := route.DeepCopy().Object["spec"].(map[string]any)["rules"]
+
+	changed, err := redirectHTTPRoute(route, []string{"web"}, &MaintenanceService{Name: "sleeping", Port: 80})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.NoError(t, err)
+
+	backendRefs := rules[0].(map[string]any)["backendRefs"].([]any)
+	assert.Equal(t, map[string]any{"name": "sleeping", "port": int64(80), "weight": int64(90)}, backendRefs[0])
+	assert.Equal(t, expectedRules.([]any)[0].(map[string]any)["backendRefs"].([]any)[1], backendRefs[1])
+
+	changed, err = restoreHTTPRoute(route, []string{"web"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotContains(t, route.GetAnnotations(), annotationOriginalBackends)
+
+	rules, _, err = unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.NoError(t, err)
+
+	restoredBackendRef := rules[0].(map[string]any)["backendRefs"].([]any)[0].(map[string]any)
+	assert.Equal(t, "web", restoredBackendRef["name"])
+	assert.InDelta(t, 8080, restoredBackendRef["port"], 0)
+}
+
+// newRoutesAPIServer creates a local stand-in for the api server with the service "default/web" and an ingress routing to it.
+// Listing the services fails on the first request and updating the ingress conflicts once. It records the requests it received.
+func newRoutesAPIServer(t *testing.T) (client, *[]string) {
+	t.Helper()
+
+	var (
+		mutex    sync.Mutex
+		requests []string
+	)
+
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: "web",
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		}}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v1/namespaces/default/services":
+			if !slices.Contains(requests[:len(requests)-1], "GET /api/v1/namespaces/default/services") {
+				w.WriteHeader(http.StatusInternalServerError)
+				writeJSON(w, &metav1.Status{Status: metav1.StatusFailure, Code: http.StatusInternalServerError})
+
+				return
+			}
+
+			writeJSON(w, &corev1.ServiceList{Items: []corev1.Service{{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+			}}})
+		case "GET /apis/networking.k8s.io/v1/namespaces/default/ingresses":
+			writeJSON(w, &networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}})
+		case "GET /apis/networking.k8s.io/v1/namespaces/default/ingresses/web":
+			writeJSON(w, &ingress)
+		case "PUT /apis/networking.k8s.io/v1/namespaces/default/ingresses/web":
+			if !slices.Contains(requests[:len(requests)-1], "PUT /apis/networking.k8s.io/v1/namespaces/default/ingresses/web") {
+				w.WriteHeader(http.StatusConflict)
+				writeJSON(w, &metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonConflict, Code: http.StatusConflict})
+
+				return
+			}
+
+			writeJSON(w, &ingress)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, &metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound})
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := &rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	// the gateway api isn't known by the rest mapper, so httproutes are skipped
+	ctrlClient, err := ctrlclient.New(config, ctrlclient.Options{Mapper: apimeta.NewDefaultRESTMapper(nil)})
+	require.NoError(t, err)
+
+	return client{
+		clientsets:          &scalable.Clientsets{Kubernetes: clientset, Client: ctrlClient},
+		pendingRouteUpdates: newPendingRouteUpdates(),
+	}, &requests
+}
+
+func TestRedirectRoutesRetriesFailedUpdates(t *testing.T) {
+	t.Parallel()
+
+	kubeclient, requests := newRoutesAPIServer(t)
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(`{
+		"metadata": {"name": "web", "namespace": "default", "uid": "web-uid"},
+		"spec": {"template": {"metadata": {"labels": {"app": "web"}}}}
+	}`))
+	require.NoError(t, err)
+
+	service := &MaintenanceService{Name: "sleeping", Port: 80}
+
+	require.Error(t, kubeclient.RedirectRoutes(workload, service, t.Context()))
+	assert.True(t, kubeclient.HasPendingRouteUpdate(workload), "failed redirects should be retried")
+
+	require.NoError(t, kubeclient.RedirectRoutes(workload, service, t.Context()))
+	assert.False(t, kubeclient.HasPendingRouteUpdate(workload))
+	assert.Contains(t, *requests, "GET /apis/networking.k8s.io/v1/namespaces/default/ingresses/web", "conflicts should reget the ingress")
+}
+
+func TestRedirectRoutesSkipsOtherNamespaces(t *testing.T) {
+	t.Parallel()
+
+	kubeclient, requests := newRoutesAPIServer(t)
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(`{
+		"metadata": {"name": "web", "namespace": "default", "uid": "web-uid"},
+		"spec": {"template": {"metadata": {"labels": {"app": "web"}}}}
+	}`))
+	require.NoError(t, err)
+
+	service := &MaintenanceService{Namespace: "maintenance", Name: "sleeping", Port: 80}
+
+	require.NoError(t, kubeclient.RedirectRoutes(workload, service, t.Context()))
+	assert.False(t, kubeclient.HasPendingRouteUpdate(workload))
+	assert.Empty(t, *requests)
+}
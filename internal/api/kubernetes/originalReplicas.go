@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateOriginalReplicas replaces the original replicas stored on the downscaled workload,
+// so it is upscaled to the replicas at the end of the downtime.
+// Only the annotation is patched without a resource version, so concurrent updates of the workload don't conflict with it.
+func (c client) UpdateOriginalReplicas(workload scalable.Workload, replicas values.Replicas, ctx context.Context) error {
+	mutated, err := workload.Copy()
+	if err != nil {
+		return fmt.Errorf("failed to copy the workload: %w", err)
+	}
+
+	scalable.StoreOriginalReplicas(mutated, replicas)
+
+	patch, err := workload.Compare(mutated)
+	if err != nil {
+		return fmt.Errorf("failed to compare the workload: %w", err)
+	}
+
+	if len(patch) == 0 {
+		slog.Debug("replicas are already stored as the original replicas", "workload", workload.GetName(), "namespace", workload.GetNamespace())
+		return nil
+	}
+
+	if c.dryRun {
+		slog.Info(
+			"running in dry run mode, would have sent patch workload request to update the original replicas",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+			"replicas", replicas.String(),
+		)
+
+		return nil
+	}
+
+	jsonPatch, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(workload.GroupVersionKind())
+	object.SetNamespace(workload.GetNamespace())
+	object.SetName(workload.GetName())
+
+	err = c.clientsets.Client.Patch(ctx, object, ctrlclient.RawPatch(types.JSONPatchType, jsonPatch))
+	if err != nil {
+		return fmt.Errorf("failed to patch the workload: %w", err)
+	}
+
+	c.auditLog.RecordWorkloadChange(audit.ActorController, audit.ActionStoreOriginalReplicas, workload, mutated, "", ctx)
+
+	return nil
+}
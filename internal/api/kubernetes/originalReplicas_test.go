@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdateOriginalReplicas(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex   sync.Mutex
+		patches []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /apis/apps/v1/namespaces/default/deployments/web", func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		assert.Equal(t, "application/json-patch+json", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+
+		patches = append(patches, string(body))
+
+		writeJSON(w, &appsv1.Deployment{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), apimeta.RESTScopeNamespace)
+
+	ctrlClient, err := ctrlclient.New(
+		&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}},
+		ctrlclient.Options{Mapper: mapper},
+	)
+	require.NoError(t, err)
+
+	kubeclient := client{clientsets: &scalable.Clientsets{Client: ctrlClient}}
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(`{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"name": "web", "namespace": "default", "resourceVersion": "1234", "annotations": {"downscaler/original-replicas": "3"}},
+		"spec": {"replicas": 0}
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, kubeclient.UpdateOriginalReplicas(workload, values.AbsoluteReplicas(3), t.Context()))
+	assert.Empty(t, patches, "replicas which are already stored shouldn't be patched")
+
+	require.NoError(t, kubeclient.UpdateOriginalReplicas(workload, values.AbsoluteReplicas(5), t.Context()))
+	require.Len(t, patches, 1)
+	assert.JSONEq(t, `[{"op":"replace","path":"/metadata/annotations/downscaler~1original-replicas","value":"5"}]`, patches[0])
+}
@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// pendingRouteUpdates keeps track of the workloads whose routes failed to be redirected or restored,
+// so they are retried even if the workload isn't scaled again. It is safe for concurrent use.
+type pendingRouteUpdates struct {
+	mutex   sync.Mutex
+	pending map[types.UID]struct{}
+}
+
+// newPendingRouteUpdates creates a new empty pendingRouteUpdates.
+func newPendingRouteUpdates() *pendingRouteUpdates {
+	return &pendingRouteUpdates{pending: make(map[types.UID]struct{})}
+}
+
+// track remembers the workload if the update of its routes failed and forgets it once it succeeded.
+func (p *pendingRouteUpdates) track(uid types.UID, err error) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err != nil {
+		p.pending[uid] = struct{}{}
+		return
+	}
+
+	delete(p.pending, uid)
+}
+
+// has checks if the update of the workload's routes is pending.
+func (p *pendingRouteUpdates) has(uid types.UID) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	_, ok := p.pending[uid]
+
+	return ok
+}
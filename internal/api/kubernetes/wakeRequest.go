@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/audit"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdateWakeRequest replaces the wake request of the workload by the absolute time it expires at or removes it if the time is nil.
+// The updated workload is fetched again, so it can be scaled afterwards.
+func (c client) UpdateWakeRequest(workload scalable.Workload, wakeUntil *time.Time, ctx context.Context) error {
+	if c.dryRun {
+		slog.Info(
+			"running in dry run mode, would have sent update workload request to update the wake request",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+		)
+
+		return nil
+	}
+
+	original, err := c.copyForAudit(workload)
+	if err != nil {
+		return err
+	}
+
+	workload.SetAnnotations(values.SetWakeAnnotations(workload.GetAnnotations(), wakeUntil))
+
+	err = c.updateWorkload(workload, original, audit.ActionWakeRequest, "", ctx)
+	if err != nil {
+		return err
+	}
+
+	err = workload.Reget(c.clientsets, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get updated workload: %w", err)
+	}
+
+	if wakeUntil != nil {
+		NewResourceLoggerForWorkload(c, workload).InfoWakeRequested(*wakeUntil, ctx)
+	}
+
+	return nil
+}
+
+// UpdateNamespaceWakeRequest replaces the wake request of the namespace by the absolute time it expires at
+// or removes it if the time is nil.
+func (c client) UpdateNamespaceWakeRequest(namespace string, wakeUntil *time.Time, ctx context.Context) error {
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have sent update namespace request to update the wake request", "namespace", namespace)
+		return nil
+	}
+
+	ns, err := c.clientsets.Kubernetes.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	ns.Annotations = values.SetWakeAnnotations(ns.Annotations, wakeUntil)
+
+	_, err = c.clientsets.Kubernetes.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update namespace: %w", err)
+	}
+
+	if wakeUntil != nil {
+		NewResourceLoggerForNamespace(c, namespace).InfoWakeRequested(*wakeUntil, ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ReviewToken authenticates the bearer token of a user with a TokenReview.
+// It returns false if the api server doesn't accept the token.
+func (c client) ReviewToken(token string, ctx context.Context) (authenticationv1.UserInfo, bool, error) {
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+
+	result, err := c.clientsets.Kubernetes.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, fmt.Errorf("failed to review token: %w", err)
+	}
+
+	if !result.Status.Authenticated {
+		return authenticationv1.UserInfo{}, false, nil
+	}
+
+	return result.Status.User, true, nil
+}
+
+// CanPatchNamespace checks with a SubjectAccessReview if the user may patch the namespace,
+// so the annotations can only be changed by users who could change them themselves.
+func (c client) CanPatchNamespace(user authenticationv1.UserInfo, namespace string, ctx context.Context) (bool, error) {
+	return c.reviewNamespaceAccess(user, "patch", namespace, ctx)
+}
+
+// CanGetNamespace checks with a SubjectAccessReview if the user may get the namespace,
+// so users only see the namespaces they could see themselves. An empty namespace checks the access to all namespaces.
+func (c client) CanGetNamespace(user authenticationv1.UserInfo, namespace string, ctx context.Context) (bool, error) {
+	return c.reviewNamespaceAccess(user, "get", namespace, ctx)
+}
+
+// reviewNamespaceAccess checks with a SubjectAccessReview if the user may use the verb on the namespace.
+func (c client) reviewNamespaceAccess(user authenticationv1.UserInfo, verb, namespace string, ctx context.Context) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:      verb,
+				Resource:  "namespaces",
+				Namespace: namespace,
+				Name:      namespace,
+			},
+		},
+	}
+
+	result, err := c.clientsets.Kubernetes.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access: %w", err)
+	}
+
+	return result.Status.Allowed, nil
+}
+
+// AnnotateNamespace merges the annotations into the annotations of the namespace.
+func (c client) AnnotateNamespace(namespace string, annotations map[string]string, ctx context.Context) error {
+	if c.dryRun {
+		slog.Info("running in dry run mode, would have sent patch namespace request to annotate the namespace", "namespace", namespace)
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	if err != nil {
+		return fmt.Errorf("failed to create namespace patch: %w", err)
+	}
+
+	_, err = c.clientsets.Kubernetes.CoreV1().Namespaces().Patch(ctx, namespace, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch namespace: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer is a local stand-in for the api server, accepting the token "valid" for "alice",
+// who may only get and patch the namespace "team-a".
+type fakeAPIServer struct {
+	mutex   sync.Mutex
+	server  *httptest.Server
+	patches map[string]string
+}
+
+func newFakeAPIServer(t *testing.T) *fakeAPIServer {
+	t.Helper()
+
+	f := &fakeAPIServer{patches: make(map[string]string)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apis/authentication.k8s.io/v1/tokenreviews", func(w http.ResponseWriter, req *http.Request) {
+		var review authenticationv1.TokenReview
+		if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if review.Spec.Token == "valid" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}},
+			}
+		}
+
+		writeJSON(w, &review)
+	})
+	mux.HandleFunc("POST /apis/authorization.k8s.io/v1/subjectaccessreviews", func(w http.ResponseWriter, req *http.Request) {
+		var review authorizationv1.SubjectAccessReview
+		if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" &&
+			(attributes.Verb == "get" || attributes.Verb == "patch") && attributes.Resource == "namespaces" && attributes.Name == "team-a"
+
+		writeJSON(w, &review)
+	})
+	mux.HandleFunc("PATCH /api/v1/namespaces/{namespace}", func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		f.mutex.Lock()
+		f.patches[req.PathValue("namespace")] = string(body)
+		f.mutex.Unlock()
+
+		writeJSON(w, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: req.PathValue("namespace")}})
+	})
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeAPIServer) newClient(t *testing.T, dryRun bool) client {
+	t.Helper()
+
+	// the stand-in only understands json
+	config := &rest.Config{Host: f.server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	return client{clientsets: &scalable.Clientsets{Kubernetes: clientset}, dryRun: dryRun}
+}
+
+func (f *fakeAPIServer) getPatch(namespace string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.patches[namespace]
+}
+
+func writeJSON(w http.ResponseWriter, object any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(object)
+}
+
+func TestReviewToken(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeAPIServer(t).newClient(t, false)
+
+	user, authenticated, err := c.ReviewToken("valid", t.Context())
+	require.NoError(t, err)
+	assert.True(t, authenticated)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, []string{"developers"}, user.Groups)
+
+	_, authenticated, err = c.ReviewToken("expired", t.Context())
+	require.NoError(t, err)
+	assert.False(t, authenticated)
+}
+
+func TestCanPatchNamespace(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeAPIServer(t).newClient(t, false)
+
+	tests := []struct {
+		name      string
+		user      authenticationv1.UserInfo
+		namespace string
+		want      bool
+	}{
+		{
+			name:      "allowed",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			namespace: "team-a",
+			want:      true,
+		},
+		{
+			name:      "other namespace",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			namespace: "team-b",
+			want:      false,
+		},
+		{
+			name:      "other user",
+			user:      authenticationv1.UserInfo{Username: "bob"},
+			namespace: "team-a",
+			want:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			allowed, err := c.CanPatchNamespace(test.user, test.namespace, t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, test.want, allowed)
+		})
+	}
+}
+
+func TestCanGetNamespace(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeAPIServer(t).newClient(t, false)
+
+	tests := []struct {
+		name      string
+		user      authenticationv1.UserInfo
+		namespace string
+		want      bool
+	}{
+		{
+			name:      "allowed",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			namespace: "team-a",
+			want:      true,
+		},
+		{
+			name:      "other namespace",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			namespace: "team-b",
+			want:      false,
+		},
+		{
+			name:      "all namespaces",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			namespace: "",
+			want:      false,
+		},
+		{
+			name:      "other user",
+			user:      authenticationv1.UserInfo{Username: "bob"},
+			namespace: "team-a",
+			want:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			allowed, err := c.CanGetNamespace(test.user, test.namespace, t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, test.want, allowed)
+		})
+	}
+}
+
+func TestAnnotateNamespace(t *testing.T) {
+	t.Parallel()
+
+	apiServer := newFakeAPIServer(t)
+
+	err := apiServer.newClient(t, false).AnnotateNamespace("team-a", map[string]string{"downscaler/wake-for": "2h"}, t.Context())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"metadata":{"annotations":{"downscaler/wake-for":"2h"}}}`, apiServer.getPatch("team-a"))
+
+	err = apiServer.newClient(t, true).AnnotateNamespace("team-b", map[string]string{"downscaler/wake-for": "2h"}, t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, apiServer.getPatch("team-b"), "nothing should be changed in dry run mode")
+}
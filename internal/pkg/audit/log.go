@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+)
+
+const (
+	// DestinationStdout writes the audit log to stdout.
+	DestinationStdout = "stdout"
+	// DestinationStderr writes the audit log to stderr.
+	DestinationStderr = "stderr"
+
+	bytesPerMegabyte = 1024 * 1024
+)
+
+// Log writes an audit record for every mutation to its destination. It is safe for concurrent use.
+// A nil Log is disabled and doesn't record anything.
+type Log struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// NewLog creates an audit log writing to stdout, stderr or a file rotated once it exceeds maxSizeMegabytes.
+// It returns nil if the destination is empty.
+func NewLog(destination string, maxSizeMegabytes, maxBackups int) (*Log, error) {
+	switch destination {
+	case "":
+		return nil, nil //nolint:nilnil // a nil audit log is disabled
+	case DestinationStdout:
+		return &Log{writer: os.Stdout}, nil
+	case DestinationStderr:
+		return &Log{writer: os.Stderr}, nil
+	}
+
+	file, err := newRotatingFile(destination, int64(maxSizeMegabytes)*bytesPerMegabyte, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Log{writer: file, closer: file}, nil
+}
+
+// NewLogWithWriter creates an audit log writing to the writer.
+func NewLogWithWriter(writer io.Writer) *Log {
+	return &Log{writer: writer}
+}
+
+// Enabled returns true if the audit log records mutations.
+func (l *Log) Enabled() bool {
+	return l != nil
+}
+
+// Write writes the record as a single json line. The time, cycle ID and deciding scope are taken from the context if not set.
+func (l *Log) Write(record *Record, ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	record.Type = recordType
+
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+
+	if record.CycleID == "" {
+		record.CycleID = getCycleID(ctx)
+	}
+
+	if record.DecidingScope == "" {
+		record.DecidingScope = getDecidingScope(ctx)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	line = append(line, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err = l.writer.Write(line)
+	if err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	return nil
+}
+
+// RecordWorkloadChange writes a record with the changes between the original and the mutated workload.
+// Failures are only logged, since the mutation already happened.
+func (l *Log) RecordWorkloadChange(
+	actor, action string,
+	original, mutated scalable.Workload,
+	cause string,
+	ctx context.Context,
+) {
+	if l == nil {
+		return
+	}
+
+	record, err := NewWorkloadRecord(actor, action, original, mutated)
+	if err != nil {
+		slog.Error("failed to create audit record", "error", err, "workload", mutated.GetName(), "namespace", mutated.GetNamespace())
+		return
+	}
+
+	record.Cause = cause
+
+	err = l.Write(record, ctx)
+	if err != nil {
+		slog.Error("failed to write audit record", "error", err, "workload", mutated.GetName(), "namespace", mutated.GetNamespace())
+	}
+}
+
+// Close closes the destination of the audit log if it is a file.
+func (l *Log) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+
+	err := l.closer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	return nil
+}
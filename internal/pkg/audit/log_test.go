@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWorkloadChange(t *testing.T) {
+	t.Parallel()
+
+	workload, err := scalable.ParseWorkloadFromRawObject("deployment", []byte(`{
+		"kind": "Deployment",
+		"metadata": {"name": "app", "namespace": "team-a"},
+		"spec": {"replicas": 3}
+	}`))
+	require.NoError(t, err)
+
+	original, err := workload.Copy()
+	require.NoError(t, err)
+
+	_, _, err = workload.ScaleDown(values.AbsoluteReplicas(0))
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+
+	ctx := WithDecidingScope(WithCycleID(t.Context(), "cycle"), "ScopeNamespace")
+
+	NewLogWithWriter(&buffer).RecordWorkloadChange(ActorController, ActionDownscale, original, workload, "decided by ScopeNamespace", ctx)
+
+	var record Record
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+	assert.Equal(t, recordType, record.Type)
+	assert.Equal(t, ActorController, record.Actor)
+	assert.Equal(t, ActionDownscale, record.Action)
+	assert.Equal(t, "Deployment", record.Kind)
+	assert.Equal(t, "team-a", record.Namespace)
+	assert.Equal(t, "app", record.Name)
+	assert.Equal(t, "cycle", record.CycleID)
+	assert.Equal(t, "ScopeNamespace", record.DecidingScope)
+	assert.Equal(t, "decided by ScopeNamespace", record.Cause)
+	assert.False(t, record.Time.IsZero())
+	assert.Contains(t, record.Changes, Change{Operation: "replace", Path: "/spec/replicas", Before: float64(3), After: float64(0)})
+}
+
+func TestDisabledLog(t *testing.T) {
+	t.Parallel()
+
+	var log *Log
+
+	assert.False(t, log.Enabled())
+	require.NoError(t, log.Write(&Record{}, t.Context()))
+	require.NoError(t, log.Close())
+
+	log, err := NewLog("", 100, 5)
+	require.NoError(t, err)
+	assert.Nil(t, log)
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"github.com/wI2L/jsondiff"
+)
+
+const (
+	// ActorController is the actor of mutations made by the downscaler.
+	ActorController = "controller"
+	// ActorWebhook is the actor of mutations made by the admission controller.
+	ActorWebhook = "webhook"
+
+	// ActionDownscale is the action of downscaling a workload.
+	ActionDownscale = "downscale"
+	// ActionUpscale is the action of upscaling a workload.
+	ActionUpscale = "upscale"
+	// ActionCleanup is the action of upscaling a workload and removing all state managed by the downscaler.
+	ActionCleanup = "cleanup"
+	// ActionRepairDrift is the action of repairing manual changes made to a downscaled workload.
+	ActionRepairDrift = "repair-drift"
+	// ActionWakeRequest is the action of converting or removing the wake request of a workload.
+	ActionWakeRequest = "wake-request"
+	// ActionStoreOriginalReplicas is the action of storing the replicas requested for a downscaled workload as its original replicas.
+	ActionStoreOriginalReplicas = "store-original-replicas"
+
+	recordType = "audit"
+)
+
+// Record is a single mutation of a workload.
+type Record struct {
+	Type          string    `json:"type"`
+	Time          time.Time `json:"time"`
+	Actor         string    `json:"actor"`
+	Action        string    `json:"action"`
+	Kind          string    `json:"kind"`
+	Namespace     string    `json:"namespace"`
+	Name          string    `json:"name"`
+	Changes       []Change  `json:"changes"`
+	DecidingScope string    `json:"decidingScope,omitempty"`
+	Cause         string    `json:"cause,omitempty"`
+	CycleID       string    `json:"cycleId,omitempty"`
+	RequestUID    string    `json:"requestUid,omitempty"`
+}
+
+// Change is a single changed value of a mutated workload.
+type Change struct {
+	Operation string `json:"op"`
+	Path      string `json:"path"`
+	Before    any    `json:"before,omitempty"`
+	After     any    `json:"after,omitempty"`
+}
+
+// NewWorkloadRecord creates a record with the changes between the original and the mutated workload.
+func NewWorkloadRecord(actor, action string, original, mutated scalable.Workload) (*Record, error) {
+	patch, err := original.Compare(mutated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare workloads: %w", err)
+	}
+
+	return &Record{
+		Actor:     actor,
+		Action:    action,
+		Kind:      mutated.GroupVersionKind().Kind,
+		Namespace: mutated.GetNamespace(),
+		Name:      mutated.GetName(),
+		Changes:   NewChanges(patch),
+	}, nil
+}
+
+// NewChanges converts the operations of the patch to changes with their values before and after the mutation.
+func NewChanges(patch jsondiff.Patch) []Change {
+	changes := make([]Change, 0, len(patch))
+
+	for _, operation := range patch {
+		changes = append(changes, Change{
+			Operation: operation.Type,
+			Path:      operation.Path,
+			Before:    operation.OldValue,
+			After:     operation.Value,
+		})
+	}
+
+	return changes
+}
+
+type contextKey int
+
+const (
+	cycleIDKey contextKey = iota
+	decidingScopeKey
+)
+
+// WithCycleID returns a copy of the context which adds the ID of the scan cycle to all records written with it.
+func WithCycleID(ctx context.Context, cycleID string) context.Context {
+	return context.WithValue(ctx, cycleIDKey, cycleID)
+}
+
+// WithDecidingScope returns a copy of the context which adds the scope deciding the scaling to all records written with it.
+func WithDecidingScope(ctx context.Context, decidingScope string) context.Context {
+	return context.WithValue(ctx, decidingScopeKey, decidingScope)
+}
+
+// getCycleID gets the ID of the scan cycle from the context.
+func getCycleID(ctx context.Context) string {
+	cycleID, _ := ctx.Value(cycleIDKey).(string)
+	return cycleID
+}
+
+// getDecidingScope gets the scope deciding the scaling from the context.
+func getDecidingScope(ctx context.Context) string {
+	decidingScope, _ := ctx.Value(decidingScopeKey).(string)
+	return decidingScope
+}
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const filePermissions = 0o600
+
+// rotatingFile is a file which is rotated once it exceeds its maximum size. It is safe for concurrent use.
+// Rotated files are renamed to "<path>.1" to "<path>.<maxBackups>", where "<path>.1" is the newest one.
+type rotatingFile struct {
+	mutex      sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// newRotatingFile opens the file at the path for appending. If maxSize is zero or less the file is never rotated.
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rotating := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	err := rotating.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return rotating, nil
+}
+
+// open opens the file at the path for appending and gets its current size.
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to get size of audit log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+
+	return nil
+}
+
+// Write writes the data to the file and syncs it to disk. The file is rotated first if the data wouldn't fit into it anymore.
+func (r *rotatingFile) Write(data []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(data)) > r.maxSize {
+		err := r.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	written, err := r.file.Write(data)
+	r.size += int64(written)
+
+	if err != nil {
+		return written, fmt.Errorf("failed to write to audit log file: %w", err)
+	}
+
+	err = r.file.Sync()
+	if err != nil {
+		return written, fmt.Errorf("failed to sync audit log file: %w", err)
+	}
+
+	return written, nil
+}
+
+// rotate closes the file, shifts the backups by one, dropping the oldest one, and opens a new file.
+// The caller has to hold the lock.
+func (r *rotatingFile) rotate() error {
+	err := r.file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close audit log file: %w", err)
+	}
+
+	if r.maxBackups < 1 {
+		err = os.Remove(r.path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove audit log file: %w", err)
+		}
+
+		return r.open()
+	}
+
+	for i := r.maxBackups - 1; i > 0; i-- {
+		err = os.Rename(r.backupPath(i), r.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit log backup: %w", err)
+		}
+	}
+
+	err = os.Rename(r.path, r.backupPath(1))
+	if err != nil {
+		return fmt.Errorf("failed to rotate audit log file: %w", err)
+	}
+
+	return r.open()
+}
+
+// backupPath gets the path of the nth backup.
+func (r *rotatingFile) backupPath(n int) string {
+	return r.path + "." + strconv.Itoa(n)
+}
+
+// Close closes the file.
+func (r *rotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err := r.file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close audit log file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		maxSize         int64
+		maxBackups      int
+		writes          []string
+		expectedFiles   map[string]string
+		unexpectedFiles []string
+	}{
+		{
+			name:          "no rotation while the file fits",
+			maxSize:       10,
+			maxBackups:    2,
+			writes:        []string{"aaaa\n", "bbbb\n"},
+			expectedFiles: map[string]string{"audit.log": "aaaa\nbbbb\n"},
+		},
+		{
+			name:       "rotates once the file exceeds the size",
+			maxSize:    10,
+			maxBackups: 2,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			expectedFiles: map[string]string{
+				"audit.log":   "cccc\n",
+				"audit.log.1": "aaaa\nbbbb\n",
+			},
+		},
+		{
+			name:       "drops the oldest backup",
+			maxSize:    5,
+			maxBackups: 2,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n"},
+			expectedFiles: map[string]string{
+				"audit.log":   "dddd\n",
+				"audit.log.1": "cccc\n",
+				"audit.log.2": "bbbb\n",
+			},
+			unexpectedFiles: []string{"audit.log.3"},
+		},
+		{
+			name:            "no backups are kept",
+			maxSize:         5,
+			maxBackups:      0,
+			writes:          []string{"aaaa\n", "bbbb\n"},
+			expectedFiles:   map[string]string{"audit.log": "bbbb\n"},
+			unexpectedFiles: []string{"audit.log.1"},
+		},
+		{
+			name:          "never rotates without a size",
+			maxSize:       0,
+			maxBackups:    2,
+			writes:        []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			expectedFiles: map[string]string{"audit.log": "aaaa\nbbbb\ncccc\n"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			file, err := newRotatingFile(filepath.Join(dir, "audit.log"), test.maxSize, test.maxBackups)
+			require.NoError(t, err)
+
+			for _, data := range test.writes {
+				_, err = file.Write([]byte(data))
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, file.Close())
+
+			for name, content := range test.expectedFiles {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(data), name)
+			}
+
+			for _, name := range test.unexpectedFiles {
+				assert.NoFileExists(t, filepath.Join(dir, name))
+			}
+		})
+	}
+}
+
+func TestRotatingFileAppendsToExistingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("aaaa\n"), filePermissions))
+
+	file, err := newRotatingFile(path, 8, 1)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("bbbb\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb\n", string(data))
+
+	backup, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa\n", string(backup))
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	k8smetrics "k8s.io/component-base/metrics"
 	"k8s.io/component-base/metrics/legacyregistry"
@@ -23,6 +25,9 @@ type Metrics struct {
 	savedCPUGauge                  *k8smetrics.GaugeVec
 	downscalerCycleDurationSeconds *k8smetrics.Gauge
 	downscalerExecutionsTotal      *k8smetrics.Counter
+	globalModeGauge                *k8smetrics.GaugeVec
+	safetyLimitExceededGauge       *k8smetrics.Gauge
+	workloadMetrics                *WorkloadMetrics
 }
 
 func NewMetrics(dryRun bool) *Metrics {
@@ -77,6 +82,18 @@ func NewMetrics(dryRun bool) *Metrics {
 				Help: "Number of cycles completed by kubedownscaler since being instantiated.",
 			},
 		),
+		globalModeGauge: k8smetrics.NewGaugeVec(
+			&k8smetrics.GaugeOpts{
+				Name: "kubedownscaler_global_mode",
+				Help: "Current mode of the global switch (resume, pause, upscale), the active mode is set to 1.",
+			}, []string{"mode"},
+		),
+		safetyLimitExceededGauge: k8smetrics.NewGauge(
+			&k8smetrics.GaugeOpts{
+				Name: "kubedownscaler_safety_limit_exceeded",
+				Help: "Set to 1 if the last cycle was aborted because it exceeded a safety limit, 0 otherwise.",
+			},
+		),
 	}
 }
 
@@ -89,6 +106,48 @@ func (m *Metrics) RegisterAll() {
 	legacyregistry.MustRegister(m.scalingErrorWorkloadGauge)
 	legacyregistry.MustRegister(m.downscalerCycleDurationSeconds)
 	legacyregistry.MustRegister(m.downscalerExecutionsTotal)
+	legacyregistry.MustRegister(m.globalModeGauge)
+	legacyregistry.MustRegister(m.safetyLimitExceededGauge)
+}
+
+// EnableWorkloadMetrics registers the metrics grouped by namespace and kind of the workloads.
+// Workloads in the name namespaces are additionally grouped by their name.
+func (m *Metrics) EnableWorkloadMetrics(dryRun bool, nameNamespaces []string) {
+	m.workloadMetrics = NewWorkloadMetrics(dryRun, nameNamespaces)
+	m.workloadMetrics.RegisterAll()
+}
+
+// UpdateWorkloadMetrics updates the metrics grouped by namespace and kind with the workloads of a finished scan cycle.
+func (m *Metrics) UpdateWorkloadMetrics(metricsEnabled bool, samples []WorkloadSample, now time.Time) {
+	if !metricsEnabled || m.workloadMetrics == nil {
+		return
+	}
+
+	m.workloadMetrics.Update(samples, now)
+}
+
+// UpdateSafetyLimitExceeded sets if the last cycle was aborted because it exceeded a safety limit.
+func (m *Metrics) UpdateSafetyLimitExceeded(metricsEnabled, exceeded bool) {
+	if !metricsEnabled {
+		return
+	}
+
+	if exceeded {
+		m.safetyLimitExceededGauge.Set(1)
+		return
+	}
+
+	m.safetyLimitExceededGauge.Set(0)
+}
+
+// UpdateGlobalMode sets the active mode of the global switch.
+func (m *Metrics) UpdateGlobalMode(metricsEnabled bool, mode string) {
+	if !metricsEnabled {
+		return
+	}
+
+	m.globalModeGauge.Reset()
+	m.globalModeGauge.WithLabelValues(mode).Set(1)
 }
 
 func (m *Metrics) UpdateMetrics(
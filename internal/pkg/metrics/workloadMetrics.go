@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"time"
+
+	k8smetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	WorkloadStateDownscaled = "downscaled"
+	WorkloadStateUpscaled   = "upscaled"
+	WorkloadStateExcluded   = "excluded"
+	WorkloadStateFailed     = "failed"
+
+	kindLabel  = "kind"
+	nameLabel  = "name"
+	stateLabel = "state"
+)
+
+// WorkloadSample is the state of a single workload after a scan cycle.
+type WorkloadSample struct {
+	Kind           string
+	Namespace      string
+	Name           string
+	State          string
+	NextTransition *time.Time
+}
+
+// workloadGroup identifies the workloads the metrics are aggregated over.
+// The name is only set for workloads in namespaces which are allowed to be labeled with the workload name.
+type workloadGroup struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// workloadGroupValues holds the aggregated values of a workload group in a single scan cycle.
+type workloadGroupValues struct {
+	states                map[string]float64
+	nextTransitionSeconds float64
+	hasNextTransition     bool
+	downscaledSeconds     float64
+	transitions           float64
+	errors                float64
+}
+
+// workloadRecord is the state of a single workload in the previous scan cycle.
+type workloadRecord struct {
+	state string
+	time  time.Time
+}
+
+// WorkloadMetrics exposes metrics grouped by namespace and kind of the workloads.
+// Workloads in the name namespaces are additionally grouped by their name, which keeps the cardinality of the metrics bounded.
+type WorkloadMetrics struct {
+	nameNamespaces map[string]struct{}
+	previous       map[string]workloadRecord
+	previousGroups map[workloadGroup]struct{}
+
+	stateGauge               *k8smetrics.GaugeVec
+	nextTransitionGauge      *k8smetrics.GaugeVec
+	downscaledSecondsCounter *k8smetrics.CounterVec
+	transitionsCounter       *k8smetrics.CounterVec
+	errorsCounter            *k8smetrics.CounterVec
+}
+
+func NewWorkloadMetrics(dryRun bool, nameNamespaces []string) *WorkloadMetrics {
+	allowedNamespaces := make(map[string]struct{}, len(nameNamespaces))
+	for _, nameNamespace := range nameNamespaces {
+		allowedNamespaces[nameNamespace] = struct{}{}
+	}
+
+	groupLabels := []string{namespace, kindLabel, nameLabel}
+
+	return &WorkloadMetrics{
+		nameNamespaces: allowedNamespaces,
+		previous:       make(map[string]workloadRecord),
+		previousGroups: make(map[workloadGroup]struct{}),
+		stateGauge: k8smetrics.NewGaugeVec(
+			&k8smetrics.GaugeOpts{
+				Name: metricName("workload_state", dryRun),
+				Help: helperDescription("workloads in each state broken down by namespace, kind and name.", dryRun),
+			}, []string{namespace, kindLabel, nameLabel, stateLabel},
+		),
+		downscaledSecondsCounter: k8smetrics.NewCounterVec(
+			&k8smetrics.CounterOpts{
+				Name: metricName("workload_downscaled_seconds_total", dryRun),
+				Help: helperDescription("seconds workloads spent downscaled broken down by namespace, kind and name.", dryRun),
+			}, groupLabels,
+		),
+		transitionsCounter: k8smetrics.NewCounterVec(
+			&k8smetrics.CounterOpts{
+				Name: metricName("workload_transitions_total", dryRun),
+				Help: helperDescription("transitions between downscaled and upscaled broken down by namespace, kind and name.", dryRun),
+			}, groupLabels,
+		),
+		nextTransitionGauge: k8smetrics.NewGaugeVec(
+			&k8smetrics.GaugeOpts{
+				Name: metricName("workload_next_transition_seconds", dryRun),
+				Help: helperDescription("seconds until the next scheduled transition of any workload broken down by namespace, kind and name.", dryRun),
+			}, groupLabels,
+		),
+		errorsCounter: k8smetrics.NewCounterVec(
+			&k8smetrics.CounterOpts{
+				Name: metricName("workload_errors_total", dryRun),
+				Help: helperDescription(
+					"scan cycles in which evaluating or scaling a workload failed broken down by namespace, kind and name.",
+					dryRun,
+				),
+			}, groupLabels,
+		),
+	}
+}
+
+func (m *WorkloadMetrics) RegisterAll() {
+	legacyregistry.MustRegister(m.stateGauge)
+	legacyregistry.MustRegister(m.nextTransitionGauge)
+	legacyregistry.MustRegister(m.downscaledSecondsCounter)
+	legacyregistry.MustRegister(m.transitionsCounter)
+	legacyregistry.MustRegister(m.errorsCounter)
+}
+
+// Update updates the metrics with the workloads of a finished scan cycle.
+// Metrics of groups which don't have any workloads anymore are deleted.
+func (m *WorkloadMetrics) Update(samples []WorkloadSample, now time.Time) {
+	groups := m.aggregate(samples, now)
+
+	for group := range m.previousGroups {
+		if _, exists := groups[group]; exists {
+			continue
+		}
+
+		labels := map[string]string{namespace: group.namespace, kindLabel: group.kind, nameLabel: group.name}
+		m.nextTransitionGauge.Delete(labels)
+		m.downscaledSecondsCounter.Delete(labels)
+		m.transitionsCounter.Delete(labels)
+		m.errorsCounter.Delete(labels)
+	}
+
+	m.previousGroups = make(map[workloadGroup]struct{}, len(groups))
+	m.stateGauge.Reset()
+
+	for group, groupValues := range groups {
+		m.previousGroups[group] = struct{}{}
+
+		for workloadState, count := range groupValues.states {
+			m.stateGauge.WithLabelValues(group.namespace, group.kind, group.name, workloadState).Set(count)
+		}
+
+		if groupValues.hasNextTransition {
+			m.nextTransitionGauge.WithLabelValues(group.namespace, group.kind, group.name).Set(groupValues.nextTransitionSeconds)
+		} else {
+			m.nextTransitionGauge.DeleteLabelValues(group.namespace, group.kind, group.name)
+		}
+
+		m.downscaledSecondsCounter.WithLabelValues(group.namespace, group.kind, group.name).Add(groupValues.downscaledSeconds)
+		m.transitionsCounter.WithLabelValues(group.namespace, group.kind, group.name).Add(groupValues.transitions)
+		m.errorsCounter.WithLabelValues(group.namespace, group.kind, group.name).Add(groupValues.errors)
+	}
+}
+
+// aggregate aggregates the samples into their groups and remembers the state of each workload for the next scan cycle.
+// Time spent downscaled is counted from the previous scan cycle if the workload was downscaled in it.
+func (m *WorkloadMetrics) aggregate(samples []WorkloadSample, now time.Time) map[workloadGroup]*workloadGroupValues {
+	groups := make(map[workloadGroup]*workloadGroupValues)
+	current := make(map[string]workloadRecord, len(samples))
+
+	for _, sample := range samples {
+		group := m.getGroup(sample)
+
+		groupValues, ok := groups[group]
+		if !ok {
+			groupValues = &workloadGroupValues{states: make(map[string]float64)}
+			groups[group] = groupValues
+		}
+
+		groupValues.states[sample.State]++
+
+		if sample.State == WorkloadStateFailed {
+			groupValues.errors++
+		}
+
+		if sample.NextTransition != nil {
+			seconds := max(sample.NextTransition.Sub(now).Seconds(), 0)
+			if !groupValues.hasNextTransition || seconds < groupValues.nextTransitionSeconds {
+				groupValues.nextTransitionSeconds = seconds
+				groupValues.hasNextTransition = true
+			}
+		}
+
+		key := sample.Kind + "/" + sample.Namespace + "/" + sample.Name
+		current[key] = workloadRecord{state: sample.State, time: now}
+
+		previous, ok := m.previous[key]
+		if !ok {
+			continue
+		}
+
+		if previous.state == WorkloadStateDownscaled {
+			groupValues.downscaledSeconds += now.Sub(previous.time).Seconds()
+		}
+
+		if isTransition(previous.state, sample.State) {
+			groupValues.transitions++
+		}
+	}
+
+	m.previous = current
+
+	return groups
+}
+
+// getGroup gets the group of the sample. The name is only part of the group in the name namespaces.
+func (m *WorkloadMetrics) getGroup(sample WorkloadSample) workloadGroup {
+	group := workloadGroup{namespace: sample.Namespace, kind: sample.Kind}
+
+	if _, ok := m.nameNamespaces[sample.Namespace]; ok {
+		group.name = sample.Name
+	}
+
+	return group
+}
+
+// isTransition returns true if the workload changed between downscaled and upscaled.
+func isTransition(previousState, currentState string) bool {
+	switch {
+	case previousState == WorkloadStateDownscaled && currentState == WorkloadStateUpscaled:
+		return true
+	case previousState == WorkloadStateUpscaled && currentState == WorkloadStateDownscaled:
+		return true
+	default:
+		return false
+	}
+}
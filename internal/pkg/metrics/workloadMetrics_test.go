@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadMetricsAggregate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 19, 0, 0, 0, time.UTC)
+	nextTransition := start.Add(time.Hour)
+	laterTransition := start.Add(2 * time.Hour)
+
+	workloadMetrics := NewWorkloadMetrics(false, []string{"team-b"})
+
+	groups := workloadMetrics.aggregate([]WorkloadSample{
+		{Kind: "Deployment", Namespace: "team-a", Name: "a", State: WorkloadStateDownscaled, NextTransition: &laterTransition},
+		{Kind: "Deployment", Namespace: "team-a", Name: "b", State: WorkloadStateUpscaled, NextTransition: &nextTransition},
+		{Kind: "Deployment", Namespace: "team-a", Name: "c", State: WorkloadStateFailed},
+		{Kind: "StatefulSet", Namespace: "team-a", Name: "d", State: WorkloadStateExcluded},
+		{Kind: "Deployment", Namespace: "team-b", Name: "e", State: WorkloadStateDownscaled},
+	}, start)
+
+	require.Len(t, groups, 3)
+
+	deployments := groups[workloadGroup{namespace: "team-a", kind: "Deployment"}]
+	require.NotNil(t, deployments)
+	assert.Equal(t, map[string]float64{
+		WorkloadStateDownscaled: 1,
+		WorkloadStateUpscaled:   1,
+		WorkloadStateFailed:     1,
+	}, deployments.states)
+	assert.True(t, deployments.hasNextTransition)
+	assert.InDelta(t, time.Hour.Seconds(), deployments.nextTransitionSeconds, 0)
+	assert.InDelta(t, 1, deployments.errors, 0)
+	assert.Zero(t, deployments.downscaledSeconds, "nothing was downscaled before the first cycle")
+	assert.Zero(t, deployments.transitions, "nothing transitioned before the first cycle")
+
+	statefulSets := groups[workloadGroup{namespace: "team-a", kind: "StatefulSet"}]
+	require.NotNil(t, statefulSets)
+	assert.False(t, statefulSets.hasNextTransition)
+
+	named := groups[workloadGroup{namespace: "team-b", kind: "Deployment", name: "e"}]
+	require.NotNil(t, named, "workloads in the name namespaces should be grouped by their name")
+
+	groups = workloadMetrics.aggregate([]WorkloadSample{
+		{Kind: "Deployment", Namespace: "team-a", Name: "a", State: WorkloadStateUpscaled},
+		{Kind: "Deployment", Namespace: "team-a", Name: "b", State: WorkloadStateDownscaled},
+		{Kind: "Deployment", Namespace: "team-b", Name: "e", State: WorkloadStateDownscaled},
+	}, start.Add(time.Minute))
+
+	deployments = groups[workloadGroup{namespace: "team-a", kind: "Deployment"}]
+	require.NotNil(t, deployments)
+	assert.InDelta(t, 2, deployments.transitions, 0)
+	assert.InDelta(t, time.Minute.Seconds(), deployments.downscaledSeconds, 0)
+	assert.Zero(t, deployments.errors)
+
+	named = groups[workloadGroup{namespace: "team-b", kind: "Deployment", name: "e"}]
+	require.NotNil(t, named)
+	assert.InDelta(t, time.Minute.Seconds(), named.downscaledSeconds, 0)
+	assert.Zero(t, named.transitions)
+}
+
+func TestNewWorkloadMetricsNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		dryRun     bool
+		wantPrefix string
+	}{
+		{
+			name:       "production",
+			dryRun:     false,
+			wantPrefix: "kubedownscaler_workload_",
+		},
+		{
+			name:       "dry run",
+			dryRun:     true,
+			wantPrefix: "kubedownscaler_potential_workload_",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			workloadMetrics := NewWorkloadMetrics(test.dryRun, nil)
+
+			for _, name := range []string{
+				workloadMetrics.stateGauge.Name,
+				workloadMetrics.nextTransitionGauge.Name,
+				workloadMetrics.downscaledSecondsCounter.Name,
+				workloadMetrics.transitionsCounter.Name,
+				workloadMetrics.errorsCounter.Name,
+			} {
+				assert.True(t, strings.HasPrefix(name, test.wantPrefix), "metric %s should start with %s", name, test.wantPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"fmt"
+)
+
+type InvalidFormatError struct {
+	format string
+}
+
+func newInvalidFormatError(format string) error {
+	return &InvalidFormatError{format: format}
+}
+
+func (i *InvalidFormatError) Error() string {
+	return fmt.Sprintf("error: invalid notification format %q, has to be either %q or %q", i.format, FormatGeneric, FormatCloudEvents)
+}
+
+type InvalidURLError struct {
+	index int
+}
+
+func newInvalidURLError(index int) error {
+	return &InvalidURLError{index: index}
+}
+
+func (i *InvalidURLError) Error() string {
+	return fmt.Sprintf("error: notification url %d is not an absolute http or https url", i.index+1)
+}
+
+var ErrMissingAllowedPrefixes = &MissingAllowedPrefixesError{}
+
+type MissingAllowedPrefixesError struct{}
+
+func (m *MissingAllowedPrefixesError) Error() string {
+	return "error: namespace routing requires at least one allowed notification url prefix"
+}
+
+type InvalidAllowedPrefixError struct {
+	index int
+}
+
+func newInvalidAllowedPrefixError(index int) error {
+	return &InvalidAllowedPrefixError{index: index}
+}
+
+func (i *InvalidAllowedPrefixError) Error() string {
+	return fmt.Sprintf("error: allowed notification url prefix %d is not an absolute http or https url", i.index+1)
+}
+
+type UnexpectedStatusError struct {
+	host       string
+	statusCode int
+}
+
+func newUnexpectedStatusError(host string, statusCode int) error {
+	return &UnexpectedStatusError{host: host, statusCode: statusCode}
+}
+
+func (u *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("error: notification receiver at %q responded with status %d", u.host, u.statusCode)
+}
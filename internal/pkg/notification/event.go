@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// EventNamespaceDownscaled is sent when all scaled workloads of a namespace are downscaled.
+	EventNamespaceDownscaled = "namespace-downscaled"
+	// EventNamespaceUpscaled is sent when all scaled workloads of a namespace are upscaled.
+	EventNamespaceUpscaled = "namespace-upscaled"
+	// EventScalingFailed is sent when evaluating or scaling workloads of a namespace started failing.
+	EventScalingFailed = "scaling-failed"
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsSource      = "kube-downscaler"
+	cloudEventsTypePrefix  = "io.github.caas-team.gokubedownscaler."
+
+	contentTypeJSON             = "application/json"
+	contentTypeCloudEventsBatch = "application/cloudevents-batch+json"
+)
+
+// Event describes a change of a single namespace in a scan cycle.
+type Event struct {
+	Type      string     `json:"type"`
+	Namespace string     `json:"namespace"`
+	Workloads []Workload `json:"workloads"`
+}
+
+// Workload is a workload affected by an event.
+type Workload struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Scaling string `json:"scaling,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Batch holds all events of a single scan cycle.
+type Batch struct {
+	CycleID string    `json:"cycleId"`
+	Time    time.Time `json:"time"`
+	DryRun  bool      `json:"dryRun"`
+	Events  []Event   `json:"events"`
+}
+
+// cloudEvent is a single event in the structured CloudEvents json format.
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            any       `json:"data"`
+}
+
+// cloudEventData is the data of a cloud event.
+type cloudEventData struct {
+	CycleID   string     `json:"cycleId"`
+	DryRun    bool       `json:"dryRun"`
+	Workloads []Workload `json:"workloads"`
+}
+
+// encodePayload encodes the batch in the format and returns the payload with its content type.
+// In the CloudEvents format the events are sent in batched mode, with one cloud event per event.
+func encodePayload(batch *Batch, format string) ([]byte, string, error) {
+	if format != FormatCloudEvents {
+		payload, err := json.Marshal(batch)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode notification: %w", err)
+		}
+
+		return payload, contentTypeJSON, nil
+	}
+
+	events := make([]cloudEvent, 0, len(batch.Events))
+
+	for _, event := range batch.Events {
+		events = append(events, cloudEvent{
+			SpecVersion:     cloudEventsSpecVersion,
+			ID:              rand.Text(),
+			Source:          cloudEventsSource,
+			Type:            cloudEventsTypePrefix + event.Type,
+			Subject:         event.Namespace,
+			Time:            batch.Time,
+			DataContentType: contentTypeJSON,
+			Data: cloudEventData{
+				CycleID:   batch.CycleID,
+				DryRun:    batch.DryRun,
+				Workloads: event.Workloads,
+			},
+		})
+	}
+
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode cloud events: %w", err)
+	}
+
+	return payload, contentTypeCloudEventsBatch, nil
+}
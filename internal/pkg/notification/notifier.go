@@ -0,0 +1,283 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatGeneric sends the batch of a scan cycle as a single json object.
+	FormatGeneric = "generic"
+	// FormatCloudEvents sends the events of a scan cycle as a batch of CloudEvents.
+	FormatCloudEvents = "cloudevents"
+
+	// AnnotationNotificationURLs sets additional urls the events of a namespace are sent to.
+	AnnotationNotificationURLs = "downscaler/notification-urls"
+
+	queueSize   = 16
+	sendTimeout = 10 * time.Second
+)
+
+// AnnotationGetter gets the annotations of a namespace.
+type AnnotationGetter func(namespace string, ctx context.Context) (map[string]string, error)
+
+// Notifier sends the events of scan cycles to the configured urls in the background.
+// A nil Notifier is disabled and doesn't send anything.
+type Notifier struct {
+	urls             []string
+	format           string
+	namespaceRouting bool
+	allowedPrefixes  []*url.URL
+	getAnnotations   AnnotationGetter
+	httpClient       *http.Client
+	queue            chan *Batch
+	done             chan struct{}
+}
+
+// NewNotifier creates a notifier sending all events to the urls. If namespace routing is enabled, the events of a namespace
+// are also sent to the urls in its notification annotation, as long as they start with one of the allowed prefixes.
+// Namespace routing requires allowed prefixes, so namespace owners can't make the downscaler send requests anywhere.
+// It returns nil if there is nowhere to send events to.
+func NewNotifier(
+	urls []string,
+	format string,
+	namespaceRouting bool,
+	allowedPrefixes []string,
+	getAnnotations AnnotationGetter,
+) (*Notifier, error) {
+	if format != FormatGeneric && format != FormatCloudEvents {
+		return nil, newInvalidFormatError(format)
+	}
+
+	for index, target := range urls {
+		if !isValidURL(target) {
+			return nil, newInvalidURLError(index)
+		}
+	}
+
+	if namespaceRouting && len(allowedPrefixes) == 0 {
+		return nil, ErrMissingAllowedPrefixes
+	}
+
+	parsedPrefixes := make([]*url.URL, 0, len(allowedPrefixes))
+
+	for index, prefix := range allowedPrefixes {
+		if !isValidURL(prefix) {
+			return nil, newInvalidAllowedPrefixError(index)
+		}
+
+		parsed, _ := url.Parse(prefix) //nolint:errcheck // already validated
+		parsedPrefixes = append(parsedPrefixes, parsed)
+	}
+
+	if len(urls) == 0 && !namespaceRouting {
+		return nil, nil //nolint:nilnil // a nil notifier is disabled
+	}
+
+	return &Notifier{
+		urls:             urls,
+		format:           format,
+		namespaceRouting: namespaceRouting,
+		allowedPrefixes:  parsedPrefixes,
+		getAnnotations:   getAnnotations,
+		httpClient:       &http.Client{Timeout: sendTimeout},
+		queue:            make(chan *Batch, queueSize),
+		done:             make(chan struct{}),
+	}, nil
+}
+
+// Run sends the queued batches until the notifier is closed.
+func (n *Notifier) Run() {
+	if n == nil {
+		return
+	}
+
+	defer close(n.done)
+
+	for batch := range n.queue {
+		n.send(batch, context.Background())
+	}
+}
+
+// Close stops accepting new batches and waits until the queued batches are sent.
+// Nothing may be notified after the notifier is closed.
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+
+	close(n.queue)
+	<-n.done
+}
+
+// Notify queues the batch to be sent without blocking. The batch is dropped if the queue is full.
+func (n *Notifier) Notify(batch *Batch) {
+	if n == nil || len(batch.Events) == 0 {
+		return
+	}
+
+	select {
+	case n.queue <- batch:
+	default:
+		slog.Warn("notification queue is full, dropping notifications", "cycleID", batch.CycleID, "events", len(batch.Events))
+	}
+}
+
+// send sends each url the events routed to it.
+func (n *Notifier) send(batch *Batch, ctx context.Context) {
+	for target, events := range n.route(batch.Events, ctx) {
+		routed := &Batch{CycleID: batch.CycleID, Time: batch.Time, DryRun: batch.DryRun, Events: events}
+
+		err := n.post(target, routed, ctx)
+		if err != nil {
+			slog.Error("failed to send notification", "error", err, "host", getHost(target), "cycleID", batch.CycleID)
+		}
+	}
+}
+
+// route groups the events by the urls they are sent to, keeping their order.
+func (n *Notifier) route(events []Event, ctx context.Context) map[string][]Event {
+	routes := make(map[string][]Event)
+	namespaceURLs := make(map[string][]string)
+
+	for _, event := range events {
+		targets, ok := namespaceURLs[event.Namespace]
+		if !ok {
+			targets = n.getNamespaceURLs(event.Namespace, ctx)
+			namespaceURLs[event.Namespace] = targets
+		}
+
+		for _, target := range targets {
+			routes[target] = append(routes[target], event)
+		}
+	}
+
+	return routes
+}
+
+// getNamespaceURLs gets the urls the events of the namespace are sent to.
+func (n *Notifier) getNamespaceURLs(namespace string, ctx context.Context) []string {
+	targets := n.urls
+
+	if !n.namespaceRouting || namespace == "" || n.getAnnotations == nil {
+		return targets
+	}
+
+	annotations, err := n.getAnnotations(namespace, ctx)
+	if err != nil {
+		slog.Error("failed to get notification urls of namespace", "error", err, "namespace", namespace)
+		return targets
+	}
+
+	routed, ok := annotations[AnnotationNotificationURLs]
+	if !ok {
+		return targets
+	}
+
+	targets = append([]string{}, targets...)
+
+	for target := range strings.SplitSeq(routed, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+
+		if !isValidURL(target) {
+			slog.Warn("ignoring invalid notification url in namespace annotation", "namespace", namespace)
+			continue
+		}
+
+		if !n.isAllowedURL(target) {
+			slog.Warn("ignoring notification url in namespace annotation, it doesn't start with an allowed prefix",
+				"namespace", namespace, "host", getHost(target))
+
+			continue
+		}
+
+		if !slices.Contains(targets, target) {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
+
+// post sends the batch to the url in the configured format.
+func (n *Notifier) post(target string, batch *Batch, ctx context.Context) error {
+	payload, contentType, err := encodePayload(batch, n.format)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		// the url error contains the whole url, which may contain tokens
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return fmt.Errorf("failed to send notification request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return newUnexpectedStatusError(getHost(target), response.StatusCode)
+	}
+
+	return nil
+}
+
+// isValidURL returns true if the url is an absolute http or https url.
+func isValidURL(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// isAllowedURL returns true if the url has the scheme and host of one of the allowed prefixes and its path starts with the
+// path of the prefix. The parts are compared separately, so e.g. "https://hooks.example.com.attacker.io" doesn't match
+// the prefix "https://hooks.example.com".
+func (n *Notifier) isAllowedURL(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range n.allowedPrefixes {
+		if parsed.Scheme == prefix.Scheme && strings.EqualFold(parsed.Host, prefix.Host) &&
+			strings.HasPrefix(parsed.EscapedPath(), prefix.EscapedPath()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getHost gets the host of the url, which can be logged without leaking tokens in the path or query of the url.
+func getHost(target string) string {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Host
+}
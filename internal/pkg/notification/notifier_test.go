@@ -0,0 +1,237 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver is a local stand-in for a notification receiver, recording all requests it gets.
+type receiver struct {
+	mutex        sync.Mutex
+	server       *httptest.Server
+	bodies       map[string][][]byte
+	contentTypes []string
+}
+
+func newReceiver(t *testing.T) *receiver {
+	t.Helper()
+
+	r := &receiver{bodies: make(map[string][][]byte)}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.bodies[req.URL.Path] = append(r.bodies[req.URL.Path], body)
+		r.contentTypes = append(r.contentTypes, req.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+func (r *receiver) getBodies(path string) [][]byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.bodies[path]
+}
+
+func newTestBatch() *Batch {
+	return &Batch{
+		CycleID: "cycle",
+		Time:    time.Date(2025, time.June, 6, 19, 0, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				Type:      EventNamespaceDownscaled,
+				Namespace: "team-a",
+				Workloads: []Workload{{Kind: "Deployment", Name: "frontend", Scaling: "down"}},
+			},
+			{
+				Type:      EventScalingFailed,
+				Namespace: "team-b",
+				Workloads: []Workload{{Kind: "Deployment", Name: "backend", Scaling: "up", Error: "conflict"}},
+			},
+		},
+	}
+}
+
+func TestNotifierRouting(t *testing.T) {
+	t.Parallel()
+
+	stub := newReceiver(t)
+
+	getAnnotations := func(namespace string, _ context.Context) (map[string]string, error) {
+		if namespace != "team-b" {
+			return map[string]string{}, nil
+		}
+
+		return map[string]string{
+			AnnotationNotificationURLs: stub.server.URL + "/team-b, not-a-url, " + stub.server.URL + "/internal, http://169.254.169.254/team-b",
+		}, nil
+	}
+
+	notifier, err := NewNotifier([]string{stub.server.URL + "/all"}, FormatGeneric, true, []string{stub.server.URL + "/team-"}, getAnnotations)
+	require.NoError(t, err)
+
+	go notifier.Run()
+
+	notifier.Notify(newTestBatch())
+	notifier.Close()
+
+	allBodies := stub.getBodies("/all")
+	require.Len(t, allBodies, 1, "all events of a cycle should be sent in a single batch")
+
+	var all Batch
+	require.NoError(t, json.Unmarshal(allBodies[0], &all))
+	assert.Equal(t, "cycle", all.CycleID)
+	assert.Len(t, all.Events, 2)
+
+	teamBodies := stub.getBodies("/team-b")
+	require.Len(t, teamBodies, 1)
+
+	var team Batch
+	require.NoError(t, json.Unmarshal(teamBodies[0], &team))
+	require.Len(t, team.Events, 1, "only the events of the namespace should be routed to its urls")
+	assert.Equal(t, "team-b", team.Events[0].Namespace)
+	assert.Equal(t, "conflict", team.Events[0].Workloads[0].Error)
+	assert.Empty(t, stub.getBodies("/internal"), "urls which don't start with an allowed prefix should be ignored")
+}
+
+func TestNotifierCloudEvents(t *testing.T) {
+	t.Parallel()
+
+	stub := newReceiver(t)
+
+	notifier, err := NewNotifier([]string{stub.server.URL + "/events"}, FormatCloudEvents, false, nil, nil)
+	require.NoError(t, err)
+
+	go notifier.Run()
+
+	notifier.Notify(newTestBatch())
+	notifier.Close()
+
+	bodies := stub.getBodies("/events")
+	require.Len(t, bodies, 1)
+	assert.Equal(t, []string{contentTypeCloudEventsBatch}, stub.contentTypes)
+
+	var events []cloudEvent
+	require.NoError(t, json.Unmarshal(bodies[0], &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, cloudEventsSpecVersion, events[0].SpecVersion)
+	assert.Equal(t, cloudEventsTypePrefix+EventNamespaceDownscaled, events[0].Type)
+	assert.Equal(t, "team-a", events[0].Subject)
+	assert.NotEmpty(t, events[0].ID)
+	assert.NotEqual(t, events[0].ID, events[1].ID)
+}
+
+func TestNewNotifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		urls             []string
+		format           string
+		namespaceRouting bool
+		allowedPrefixes  []string
+		wantErr          bool
+		wantNil          bool
+	}{
+		{
+			name:    "disabled without urls",
+			format:  FormatGeneric,
+			wantNil: true,
+		},
+		{
+			name:             "enabled by namespace routing",
+			format:           FormatGeneric,
+			namespaceRouting: true,
+			allowedPrefixes:  []string{"https://hooks.example.com/"},
+		},
+		{
+			name:             "namespace routing without allowed prefixes",
+			format:           FormatGeneric,
+			namespaceRouting: true,
+			wantErr:          true,
+		},
+		{
+			name:             "invalid allowed prefix",
+			format:           FormatGeneric,
+			namespaceRouting: true,
+			allowedPrefixes:  []string{"hooks.example.com"},
+			wantErr:          true,
+		},
+		{
+			name:    "invalid format",
+			urls:    []string{"https://example.com"},
+			format:  "xml",
+			wantErr: true,
+		},
+		{
+			name:    "invalid url",
+			urls:    []string{"example.com/hook"},
+			format:  FormatGeneric,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			notifier, err := NewNotifier(test.urls, test.format, test.namespaceRouting, test.allowedPrefixes, nil)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantNil, notifier == nil)
+		})
+	}
+}
+
+func TestNotifierIsAllowedURL(t *testing.T) {
+	t.Parallel()
+
+	notifier, err := NewNotifier(nil, FormatGeneric, true, []string{"https://hooks.example.com/services/"}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		target string
+		want   bool
+	}{
+		{name: "matching prefix", target: "https://hooks.example.com/services/team-a", want: true},
+		{name: "host is case insensitive", target: "https://HOOKS.example.com/services/team-a", want: true},
+		{name: "other path", target: "https://hooks.example.com/admin", want: false},
+		{name: "other scheme", target: "http://hooks.example.com/services/team-a", want: false},
+		{name: "other host with the prefix", target: "https://hooks.example.com.attacker.io/services/team-a", want: false},
+		{name: "user info", target: "https://hooks.example.com@attacker.io/services/team-a", want: false},
+		{name: "other port", target: "https://hooks.example.com:8443/services/team-a", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, notifier.isAllowedURL(test.target))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package scalable
+
+import "maps"
+
+// annotationWakeUntil is set by the downscaler when it resolves a downscaler/wake-for request of a workload.
+const annotationWakeUntil = "downscaler/wake-until"
+
+// cleanableResource is a resource with state managed by the downscaler outside of its annotations.
+type cleanableResource interface {
+	// removeDownscalerState removes the state managed by the downscaler, returns false if there was none
+	removeDownscalerState() bool
+}
+
+// IsDownscalerStateAnnotation checks if the annotation holds state managed by the downscaler instead of being set by users.
+func IsDownscalerStateAnnotation(annotation string) bool {
+	return isOriginalStateAnnotation(annotation) || annotation == annotationWakeUntil
+}
+
+// RemoveDownscalerState removes all annotations and selectors managed by the downscaler from the workload.
+// Changes won't be made on Kubernetes until Update() is called. It returns false if the workload had no downscaler state.
+func RemoveDownscalerState(workload Workload) bool {
+	changed := false
+
+	annotations := maps.Clone(workload.GetAnnotations())
+	for annotation := range annotations {
+		if !IsDownscalerStateAnnotation(annotation) {
+			continue
+		}
+
+		delete(annotations, annotation)
+
+		changed = true
+	}
+
+	if changed {
+		workload.SetAnnotations(annotations)
+	}
+
+	var resource any = workload
+
+	switch wrapper := workload.(type) {
+	case *replicaScaledWorkload:
+		resource = wrapper.replicaScaledResource
+	case *suspendScaledWorkload:
+		resource = wrapper.suspendScaledResource
+	}
+
+	if cleanable, ok := resource.(cleanableResource); ok && cleanable.removeDownscalerState() {
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,73 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemoveDownscalerState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		workload        Workload
+		wantChanged     bool
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "deployment with original state",
+			workload: &replicaScaledWorkload{&deployment{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotationOriginalReplicas: "3",
+					annotationDownscaleRecord:  `{"originalReplicas":3,"downscaledReplicas":0}`,
+					annotationWakeUntil:        "2026-01-01T00:00:00Z",
+					"downscaler/downtime":      "always",
+				},
+			}}}},
+			wantChanged:     true,
+			wantAnnotations: map[string]string{"downscaler/downtime": "always"},
+		},
+		{
+			name: "deployment without original state",
+			workload: &replicaScaledWorkload{&deployment{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"downscaler/downtime": "always"},
+			}}}},
+			wantChanged:     false,
+			wantAnnotations: map[string]string{"downscaler/downtime": "always"},
+		},
+		{
+			name: "deployment with leftover wake request",
+			workload: &replicaScaledWorkload{&deployment{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationWakeUntil: "2026-01-01T00:00:00Z"},
+			}}}},
+			wantChanged:     true,
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name: "daemonset with leftover node selector",
+			workload: func() Workload {
+				daemonset := &daemonSet{&appsv1.DaemonSet{}}
+				daemonset.Spec.Template.Spec.NodeSelector = map[string]string{labelMatchNone: labelMatchNoneValue}
+
+				return daemonset
+			}(),
+			wantChanged: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.wantChanged, RemoveDownscalerState(test.workload))
+			assert.Equal(t, test.wantAnnotations, test.workload.GetAnnotations())
+
+			if daemonset, ok := test.workload.(*daemonSet); ok {
+				assert.NotContains(t, daemonset.Spec.Template.Spec.NodeSelector, labelMatchNone)
+			}
+		})
+	}
+}
@@ -47,6 +47,11 @@ type cronJob struct {
 	*batch.CronJob
 }
 
+// getPodTemplate gets the template of the pods managed by the CronJob.
+func (c *cronJob) getPodTemplate() *v1.PodTemplateSpec {
+	return &c.Spec.JobTemplate.Spec.Template
+}
+
 func (c *cronJob) GetChildren(ctx context.Context, clientsets *Clientsets) ([]Workload, error) {
 	activeJobs := c.Status.Active
 
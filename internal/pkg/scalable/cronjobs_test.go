@@ -0,0 +1,63 @@
+package scalable
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestCronJob_GetChildren(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /apis/batch/v1/namespaces/default/jobs/active-job", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(&batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "active-job", Namespace: "default"}})
+		assert.NoError(t, err)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{
+		Host:          server.URL,
+		ContentConfig: rest.ContentConfig{ContentType: "application/json"},
+	})
+	require.NoError(t, err)
+
+	workload := &suspendScaledWorkload{&cronJob{&batch.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cronjob", Namespace: "default"},
+		Status:     batch.CronJobStatus{Active: []corev1.ObjectReference{{Name: "active-job", Namespace: "default"}}},
+	}}}
+
+	parent, isParent := GetParentWorkload(workload)
+	require.True(t, isParent)
+
+	children, err := parent.GetChildren(t.Context(), &Clientsets{Kubernetes: clientset})
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, "active-job", children[0].GetName())
+
+	_, updated, err := children[0].ScaleDown(values.AbsoluteReplicas(0))
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	child, ok := children[0].(*suspendScaledWorkload)
+	require.True(t, ok)
+
+	activeJob, ok := child.suspendScaledResource.(*job)
+	require.True(t, ok)
+	require.NotNil(t, activeJob.Spec.Suspend)
+	assert.True(t, *activeJob.Spec.Suspend)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	"github.com/wI2L/jsondiff"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -51,6 +52,22 @@ type daemonSet struct {
 	*appsv1.DaemonSet
 }
 
+// getPodTemplate gets the template of the pods managed by the DaemonSet.
+func (d *daemonSet) getPodTemplate() *corev1.PodTemplateSpec {
+	return &d.Spec.Template
+}
+
+// removeDownscalerState removes the node selector used by the downscaler to stop the pods of the DaemonSet.
+func (d *daemonSet) removeDownscalerState() bool {
+	if _, hasLabel := d.Spec.Template.Spec.NodeSelector[labelMatchNone]; !hasLabel {
+		return false
+	}
+
+	delete(d.Spec.Template.Spec.NodeSelector, labelMatchNone)
+
+	return true
+}
+
 // ScaleUp scales the resource up.
 func (d *daemonSet) ScaleUp() (bool, error) {
 	_, err := getOriginalReplicas(d)
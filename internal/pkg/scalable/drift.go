@@ -0,0 +1,151 @@
+package scalable
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+const annotationDownscaleRecord = "downscaler/downscale-record"
+
+// downscaleRecord records the scaling done by the downscaler, so manual changes made during downtime can be detected.
+type downscaleRecord struct {
+	OriginalReplicas   int32 `json:"originalReplicas"`
+	DownscaledReplicas int32 `json:"downscaledReplicas"`
+}
+
+// Drift describes manual changes made to a workload while it was downscaled.
+type Drift struct {
+	// OriginalReplicas are the original replicas recorded by the downscaler
+	OriginalReplicas int32
+	// DownscaledReplicas are the replicas the downscaler set on the workload
+	DownscaledReplicas int32
+	// CurrentReplicas are the replicas currently set on the workload
+	CurrentReplicas int32
+	// OriginalReplicasRemoved is true if the original replicas annotation was removed from the workload
+	OriginalReplicasRemoved bool
+}
+
+// String describes the manual changes.
+func (d *Drift) String() string {
+	if d.CurrentReplicas == d.DownscaledReplicas {
+		return fmt.Sprintf("the %s annotation was removed while the workload was downscaled", annotationOriginalReplicas)
+	}
+
+	return fmt.Sprintf(
+		"the replicas were manually changed from %d to %d while the workload was downscaled",
+		d.DownscaledReplicas,
+		d.CurrentReplicas,
+	)
+}
+
+// RepairDrift detects manual changes made to a downscaled workload and repairs them according to the drift policy.
+// The changes are only made on the workload in memory. It returns nil if no drift was detected.
+// Drift can only be detected on replica scaled workloads which were downscaled by a downscaler recording its scaling.
+func RepairDrift(workload Workload, policy values.DriftPolicy) (*Drift, error) {
+	replicaScaled, ok := workload.(*replicaScaledWorkload)
+	if !ok {
+		return nil, nil //nolint:nilnil // a nil drift means no drift was detected
+	}
+
+	record, err := getDownscaleRecord(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, nil //nolint:nilnil // a nil drift means no drift was detected
+	}
+
+	currentReplicas, err := replicaScaled.getReplicas()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current replicas for workload: %w", err)
+	}
+
+	currentReplicasInt32, err := currentReplicas.AsInt32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert current replicas to int32: %w", err)
+	}
+
+	_, isOriginalReplicasSet, err := getOriginalReplicasInt32(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	if isOriginalReplicasSet && currentReplicasInt32 == record.DownscaledReplicas {
+		return nil, nil //nolint:nilnil // a nil drift means no drift was detected
+	}
+
+	drift := &Drift{
+		OriginalReplicas:        record.OriginalReplicas,
+		DownscaledReplicas:      record.DownscaledReplicas,
+		CurrentReplicas:         currentReplicasInt32,
+		OriginalReplicasRemoved: !isOriginalReplicasSet,
+	}
+
+	switch policy {
+	case values.DriftPolicyIgnore:
+		return drift, nil
+	case values.DriftPolicyAdopt:
+		if currentReplicasInt32 != record.DownscaledReplicas {
+			record.OriginalReplicas = currentReplicasInt32
+			setOriginalReplicas(values.AbsoluteReplicas(currentReplicasInt32), workload)
+		}
+	case values.DriftPolicyRedownscale:
+		// the recorded original replicas are kept
+	}
+
+	if !isOriginalReplicasSet {
+		setOriginalReplicas(values.AbsoluteReplicas(record.OriginalReplicas), workload)
+	}
+
+	err = replicaScaled.setReplicas(record.DownscaledReplicas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set replicas for workload: %w", err)
+	}
+
+	setDownscaleRecord(record, workload)
+
+	return drift, nil
+}
+
+// setDownscaleRecord sets the downscale record annotation on the workload.
+func setDownscaleRecord(record *downscaleRecord, workload Workload) {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		// the record only consists of integers and can always be marshaled
+		return
+	}
+
+	annotations := workload.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[annotationDownscaleRecord] = string(recordBytes)
+
+	workload.SetAnnotations(annotations)
+}
+
+// getDownscaleRecord gets the downscale record annotation on the workload. nil if it isn't set.
+func getDownscaleRecord(workload Workload) (*downscaleRecord, error) {
+	recordString, ok := workload.GetAnnotations()[annotationDownscaleRecord]
+	if !ok {
+		return nil, nil //nolint:nilnil // a nil record means the workload wasn't downscaled with a record
+	}
+
+	var record downscaleRecord
+	if err := json.Unmarshal([]byte(recordString), &record); err != nil {
+		return nil, fmt.Errorf("failed to parse downscale record annotation on workload: %w", err)
+	}
+
+	return &record, nil
+}
+
+// removeDownscaleRecord removes the downscale record annotation from the workload.
+func removeDownscaleRecord(workload Workload) {
+	annotations := workload.GetAnnotations()
+	delete(annotations, annotationDownscaleRecord)
+	workload.SetAnnotations(annotations)
+}
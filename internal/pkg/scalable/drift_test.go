@@ -0,0 +1,138 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestRepairDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		replicas             int32
+		originalReplicas     values.Replicas
+		record               *downscaleRecord
+		policy               values.DriftPolicy
+		wantDrift            *Drift
+		wantReplicas         values.Replicas
+		wantOriginalReplicas values.Replicas
+	}{
+		{
+			name:                 "no record",
+			replicas:             2,
+			originalReplicas:     values.AbsoluteReplicas(5),
+			record:               nil,
+			policy:               values.DriftPolicyRedownscale,
+			wantDrift:            nil,
+			wantReplicas:         values.AbsoluteReplicas(2),
+			wantOriginalReplicas: values.AbsoluteReplicas(5),
+		},
+		{
+			name:                 "no drift",
+			replicas:             0,
+			originalReplicas:     values.AbsoluteReplicas(5),
+			record:               &downscaleRecord{OriginalReplicas: 5, DownscaledReplicas: 0},
+			policy:               values.DriftPolicyRedownscale,
+			wantDrift:            nil,
+			wantReplicas:         values.AbsoluteReplicas(0),
+			wantOriginalReplicas: values.AbsoluteReplicas(5),
+		},
+		{
+			name:                 "manual replicas redownscaled",
+			replicas:             2,
+			originalReplicas:     values.AbsoluteReplicas(5),
+			record:               &downscaleRecord{OriginalReplicas: 5, DownscaledReplicas: 0},
+			policy:               values.DriftPolicyRedownscale,
+			wantDrift:            &Drift{OriginalReplicas: 5, DownscaledReplicas: 0, CurrentReplicas: 2},
+			wantReplicas:         values.AbsoluteReplicas(0),
+			wantOriginalReplicas: values.AbsoluteReplicas(5),
+		},
+		{
+			name:                 "manual replicas adopted",
+			replicas:             2,
+			originalReplicas:     values.AbsoluteReplicas(5),
+			record:               &downscaleRecord{OriginalReplicas: 5, DownscaledReplicas: 0},
+			policy:               values.DriftPolicyAdopt,
+			wantDrift:            &Drift{OriginalReplicas: 5, DownscaledReplicas: 0, CurrentReplicas: 2},
+			wantReplicas:         values.AbsoluteReplicas(0),
+			wantOriginalReplicas: values.AbsoluteReplicas(2),
+		},
+		{
+			name:                 "manual replicas ignored",
+			replicas:             2,
+			originalReplicas:     values.AbsoluteReplicas(5),
+			record:               &downscaleRecord{OriginalReplicas: 5, DownscaledReplicas: 0},
+			policy:               values.DriftPolicyIgnore,
+			wantDrift:            &Drift{OriginalReplicas: 5, DownscaledReplicas: 0, CurrentReplicas: 2},
+			wantReplicas:         values.AbsoluteReplicas(2),
+			wantOriginalReplicas: values.AbsoluteReplicas(5),
+		},
+		{
+			name:                 "removed original replicas restored",
+			replicas:             0,
+			originalReplicas:     nil,
+			record:               &downscaleRecord{OriginalReplicas: 5, DownscaledReplicas: 0},
+			policy:               values.DriftPolicyAdopt,
+			wantDrift:            &Drift{OriginalReplicas: 5, DownscaledReplicas: 0, CurrentReplicas: 0, OriginalReplicasRemoved: true},
+			wantReplicas:         values.AbsoluteReplicas(0),
+			wantOriginalReplicas: values.AbsoluteReplicas(5),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			workload := &replicaScaledWorkload{&deployment{&appsv1.Deployment{}}}
+			_ = workload.setReplicas(test.replicas)
+
+			if test.originalReplicas != nil {
+				setOriginalReplicas(test.originalReplicas, workload)
+			}
+
+			if test.record != nil {
+				setDownscaleRecord(test.record, workload)
+			}
+
+			drift, err := RepairDrift(workload, test.policy)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantDrift, drift)
+
+			replicas, err := workload.getReplicas()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantReplicas, replicas)
+
+			originalReplicas, err := getOriginalReplicas(workload)
+			if test.wantOriginalReplicas != nil {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.wantOriginalReplicas, originalReplicas)
+		})
+	}
+}
+
+func TestDownscaleRecordLifecycle(t *testing.T) {
+	t.Parallel()
+
+	workload := &replicaScaledWorkload{&deployment{&appsv1.Deployment{}}}
+	_ = workload.setReplicas(3)
+
+	_, changed, err := workload.ScaleDown(values.AbsoluteReplicas(1))
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	record, err := getDownscaleRecord(workload)
+	require.NoError(t, err)
+	assert.Equal(t, &downscaleRecord{OriginalReplicas: 3, DownscaledReplicas: 1}, record)
+
+	changed, err = workload.ScaleUp()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotContains(t, workload.GetAnnotations(), annotationDownscaleRecord)
+}
@@ -88,3 +88,33 @@ func newUnexpectedReplicasTypeError(val any, kind, namespace, name string) error
 func (e *UnexpectedReplicasTypeError) Error() string {
 	return fmt.Sprintf("unexpected type %s for spec.replicas on %s %s/%s", e.valType, e.kind, e.namespace, e.name)
 }
+
+type UnsupportedDownscaleReplicasError struct {
+	kind     string
+	replicas string
+}
+
+func newUnsupportedDownscaleReplicasError(kind, replicas string) error {
+	return &UnsupportedDownscaleReplicasError{kind: kind, replicas: replicas}
+}
+
+func (u *UnsupportedDownscaleReplicasError) Error() string {
+	return fmt.Sprintf("error: downscale replicas %q are not supported for %q, use 0 or a percentage instead", u.replicas, u.kind)
+}
+
+type RedisClusterSizeError struct {
+	namespace string
+	name      string
+	replicas  int32
+}
+
+func newRedisClusterSizeError(namespace, name string, replicas int32) error {
+	return &RedisClusterSizeError{namespace: namespace, name: name, replicas: replicas}
+}
+
+func (r *RedisClusterSizeError) Error() string {
+	return fmt.Sprintf(
+		"error: can't scale RedisCluster %s/%s to a clusterSize of %d, the redis operator requires at least %d leaders",
+		r.namespace, r.name, r.replicas, minRedisClusterSize,
+	)
+}
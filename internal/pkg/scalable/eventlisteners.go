@@ -0,0 +1,190 @@
+//nolint:dupl // necessary to handle different workload types separately
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var eventListenerGVK = schema.GroupVersionKind{
+	Group: "triggers.tekton.dev", Version: "v1beta1", Kind: "EventListener",
+}
+
+// eventListener wraps an unstructured Tekton EventListener CR. The unstructured approach
+// is used to avoid depending on the tekton triggers repository for a single CRD.
+type eventListener struct {
+	*unstructured.Unstructured
+}
+
+// getEventListeners is the getResourceFunc for EventListener.
+func getEventListeners(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   eventListenerGVK.Group,
+		Version: eventListenerGVK.Version,
+		Kind:    eventListenerGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("tekton triggers CRD not found in cluster, skipping", "kind", eventListenerGVK.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get eventlisteners: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, eventListenerGVK)
+		results = append(results, &replicaScaledWorkload{&eventListener{&item}})
+	}
+
+	return results, nil
+}
+
+// parseEventListenerFromBytes parses the admission review and returns the eventlistener wrapped in a Workload.
+func parseEventListenerFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode eventlistener: %w", err)
+	}
+
+	return &replicaScaledWorkload{&eventListener{&u}}, nil
+}
+
+// getReplicas gets the current amount of replicas of the resource.
+func (e *eventListener) getReplicas() (values.Replicas, error) {
+	val, found, err := unstructured.NestedFieldNoCopy(e.Object, "spec", "resources", "kubernetesResource", "replicas")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get spec.resources.kubernetesResource.replicas for %s %s/%s: %w",
+			e.GetKind(), e.GetNamespace(), e.GetName(), err,
+		)
+	}
+
+	if !found {
+		// tekton triggers runs a single replica if spec.resources.kubernetesResource.replicas is not set
+		return values.AbsoluteReplicas(1), nil
+	}
+
+	replicas, ok := unstructuredReplicasToInt32(val)
+	if !ok {
+		return nil, newUnexpectedReplicasTypeError(val, e.GetKind(), e.GetNamespace(), e.GetName())
+	}
+
+	return values.AbsoluteReplicas(replicas), nil
+}
+
+// setReplicas sets the amount of replicas on the resource.
+func (e *eventListener) setReplicas(replicas int32) error {
+	err := unstructured.SetNestedField(e.Object, int64(replicas), "spec", "resources", "kubernetesResource", "replicas")
+	if err != nil {
+		return fmt.Errorf(
+			"failed to set spec.resources.kubernetesResource.replicas for %s %s/%s: %w",
+			e.GetKind(), e.GetNamespace(), e.GetName(), err,
+		)
+	}
+
+	return nil
+}
+
+// getSavedResourcesRequests calculates the total saved resources requests when downscaling the EventListener.
+func (e *eventListener) getSavedResourcesRequests(diffReplicas int32) *metrics.SavedResources {
+	containers, _, err := unstructured.NestedSlice(
+		e.Object,
+		"spec", "resources", "kubernetesResource", "spec", "template", "spec", "containers",
+	)
+	if err != nil {
+		return metrics.NewSavedResources(0, 0)
+	}
+
+	var totalSavedCPU, totalSavedMemory float64
+
+	for _, container := range containers {
+		containerObject, ok := container.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		savedResources := getUnstructuredSavedResources(containerObject, diffReplicas, "resources")
+		totalSavedCPU += savedResources.TotalCPU()
+		totalSavedMemory += savedResources.TotalMemory()
+	}
+
+	return metrics.NewSavedResources(totalSavedCPU, totalSavedMemory)
+}
+
+// Copy creates a deep copy of the workload.
+func (e *eventListener) Copy() (Workload, error) {
+	if e.Object == nil {
+		return nil, newNilUnderlyingObjectError(e.GetKind())
+	}
+
+	return &replicaScaledWorkload{
+		replicaScaledResource: &eventListener{
+			Unstructured: e.DeepCopy(),
+		},
+	}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (e *eventListener) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	rswCopy, ok := workloadCopy.(*replicaScaledWorkload)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*replicaScaledWorkload)(nil), workloadCopy)
+	}
+
+	eventListenerCopy, ok := rswCopy.replicaScaledResource.(*eventListener)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*eventListener)(nil), rswCopy.replicaScaledResource)
+	}
+
+	if e.Object == nil || eventListenerCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(e.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(e.Object, eventListenerCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", e.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (e *eventListener) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, eventListenerGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: e.GetNamespace(), Name: e.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", e.GetKind(), e.GetNamespace(), e.GetName(), err)
+	}
+
+	e.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (e *eventListener) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, e.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", e.GetKind(), e.GetNamespace(), e.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestEventListener builds an eventListener with spec.resources.kubernetesResource.replicas set to the given raw value.
+// Pass nil to omit the replicas entirely.
+func newTestEventListener(replicasVal any) *eventListener {
+	kubernetesResource := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{
+							"resources": map[string]any{
+								"requests": map[string]any{"cpu": "250m", "memory": "128Mi"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if replicasVal != nil {
+		kubernetesResource["replicas"] = replicasVal
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      "test-eventlistener",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"resources": map[string]any{
+				"kubernetesResource": kubernetesResource,
+			},
+		},
+	}}
+	u.SetGroupVersionKind(eventListenerGVK)
+
+	return &eventListener{Unstructured: u}
+}
+
+func TestEventListener_ScaleDown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		replicasVal       any
+		wantOriginal      string
+		wantSavedCPU      float64
+		wantSavedMemory   float64
+		wantReplicasAfter values.Replicas
+	}{
+		{
+			name:              "explicit replicas",
+			replicasVal:       int64(2),
+			wantOriginal:      "2",
+			wantSavedCPU:      0.5,
+			wantSavedMemory:   2 * 128 * 1024 * 1024,
+			wantReplicasAfter: values.AbsoluteReplicas(0),
+		},
+		{
+			name:              "unset replicas defaults to 1",
+			replicasVal:       nil,
+			wantOriginal:      "1",
+			wantSavedCPU:      0.25,
+			wantSavedMemory:   128 * 1024 * 1024,
+			wantReplicasAfter: values.AbsoluteReplicas(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			workload := &replicaScaledWorkload{newTestEventListener(test.replicasVal)}
+
+			savedResources, updated, err := workload.ScaleDown(values.AbsoluteReplicas(0))
+			require.NoError(t, err)
+			assert.True(t, updated)
+			assert.InDelta(t, test.wantSavedCPU, savedResources.TotalCPU(), 0.001)
+			assert.InDelta(t, test.wantSavedMemory, savedResources.TotalMemory(), 1)
+			assert.Equal(t, test.wantOriginal, workload.GetAnnotations()[annotationOriginalReplicas])
+
+			replicas, err := workload.getReplicas()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantReplicasAfter, replicas)
+		})
+	}
+}
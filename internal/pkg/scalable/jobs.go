@@ -10,6 +10,7 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	"github.com/wI2L/jsondiff"
 	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,11 @@ type job struct {
 	*batch.Job
 }
 
+// getPodTemplate gets the template of the pods managed by the Job.
+func (j *job) getPodTemplate() *corev1.PodTemplateSpec {
+	return &j.Spec.Template
+}
+
 // nolint: nonamedreturns // getSuspend gets the current value of the suspend field on the job and the target downscale state for it.
 func (j *job) getSuspend() (currentValue, targetDownscaleState values.Replicas) {
 	current := false
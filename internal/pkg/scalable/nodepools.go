@@ -0,0 +1,286 @@
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	annotationOriginalLimits = "downscaler/original-limits"
+	percentageBase           = 100
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var nodePoolGVK = schema.GroupVersionKind{
+	Group: "karpenter.sh", Version: "v1", Kind: "NodePool",
+}
+
+//nolint:gochecknoglobals // resources of the node pool limits which are lowered during downtime
+var nodePoolScaledLimits = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// nodePool wraps an unstructured Karpenter NodePool CR. The unstructured approach
+// is used to avoid depending on the karpenter repository for a single CRD.
+type nodePool struct {
+	*unstructured.Unstructured
+}
+
+// getNodePools is the getResourceFunc for NodePools. NodePools are cluster scoped, so the namespace is ignored.
+func getNodePools(_ string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   nodePoolGVK.Group,
+		Version: nodePoolGVK.Version,
+		Kind:    nodePoolGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("karpenter CRD not found in cluster, skipping", "kind", nodePoolGVK.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get nodepools: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, nodePoolGVK)
+		results = append(results, &nodePool{&item})
+	}
+
+	return results, nil
+}
+
+// parseNodePoolFromBytes parses the admission review and returns the nodepool wrapped in a Workload.
+func parseNodePoolFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode nodepool: %w", err)
+	}
+
+	return &nodePool{&u}, nil
+}
+
+func (n *nodePool) AllowPercentageReplicas() bool {
+	return true
+}
+
+// getLimits gets the cpu and memory values of spec.limits. Resources without a limit are not included.
+func (n *nodePool) getLimits() (map[corev1.ResourceName]resource.Quantity, error) {
+	limits := make(map[corev1.ResourceName]resource.Quantity, len(nodePoolScaledLimits))
+
+	for _, resourceName := range nodePoolScaledLimits {
+		value, found, err := unstructured.NestedFieldNoCopy(n.Object, "spec", "limits", string(resourceName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spec.limits.%s for %s %s: %w", resourceName, n.GetKind(), n.GetName(), err)
+		}
+
+		if !found {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(fmt.Sprint(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spec.limits.%s for %s %s: %w", resourceName, n.GetKind(), n.GetName(), err)
+		}
+
+		limits[resourceName] = quantity
+	}
+
+	return limits, nil
+}
+
+// setLimits sets the cpu and memory values of spec.limits. Resources not included in limits are removed,
+// and spec.limits is removed entirely if no limits are left, so node pools without limits are restored as such.
+func (n *nodePool) setLimits(limits map[corev1.ResourceName]resource.Quantity) error {
+	for _, resourceName := range nodePoolScaledLimits {
+		quantity, ok := limits[resourceName]
+		if !ok {
+			unstructured.RemoveNestedField(n.Object, "spec", "limits", string(resourceName))
+			continue
+		}
+
+		err := unstructured.SetNestedField(n.Object, quantity.String(), "spec", "limits", string(resourceName))
+		if err != nil {
+			return fmt.Errorf("failed to set spec.limits.%s for %s %s: %w", resourceName, n.GetKind(), n.GetName(), err)
+		}
+	}
+
+	remainingLimits, found, err := unstructured.NestedMap(n.Object, "spec", "limits")
+	if err != nil {
+		return fmt.Errorf("failed to get spec.limits for %s %s: %w", n.GetKind(), n.GetName(), err)
+	}
+
+	if found && len(remainingLimits) == 0 {
+		unstructured.RemoveNestedField(n.Object, "spec", "limits")
+	}
+
+	return nil
+}
+
+// getDownscaleLimits calculates the limits during downtime.
+// Percentage replicas lower every existing limit to the percentage of its value, absolute replicas have to be 0
+// and set all limits to 0, so no new nodes are launched.
+func getDownscaleLimits(
+	limits map[corev1.ResourceName]resource.Quantity,
+	downscaleReplicas values.Replicas,
+) (map[corev1.ResourceName]resource.Quantity, error) {
+	downscaleLimits := make(map[corev1.ResourceName]resource.Quantity, len(nodePoolScaledLimits))
+
+	percentage, isPercentage := downscaleReplicas.(values.PercentageReplicas)
+	if isPercentage {
+		for resourceName, quantity := range limits {
+			downscaleLimits[resourceName] = *resource.NewMilliQuantity(
+				quantity.MilliValue()*int64(percentage)/percentageBase,
+				quantity.Format,
+			)
+		}
+
+		return downscaleLimits, nil
+	}
+
+	replicas, err := downscaleReplicas.AsInt32()
+	if err != nil || replicas != 0 {
+		return nil, newUnsupportedDownscaleReplicasError(nodePoolGVK.Kind, downscaleReplicas.String())
+	}
+
+	for _, resourceName := range nodePoolScaledLimits {
+		downscaleLimits[resourceName] = resource.MustParse("0")
+	}
+
+	return downscaleLimits, nil
+}
+
+// ScaleUp scales the resource up.
+func (n *nodePool) ScaleUp() (bool, error) {
+	annotations := n.GetAnnotations()
+
+	originalLimitsString, ok := annotations[annotationOriginalLimits]
+	if !ok {
+		slog.Debug("original limits are not set, skipping", "workload", n.GetName())
+		return false, nil
+	}
+
+	var originalLimits map[corev1.ResourceName]resource.Quantity
+
+	err := json.Unmarshal([]byte(originalLimitsString), &originalLimits)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse original limits annotation on workload: %w", err)
+	}
+
+	err = n.setLimits(originalLimits)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore original limits for workload: %w", err)
+	}
+
+	delete(annotations, annotationOriginalLimits)
+	n.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+// ScaleDown scales the resource down.
+func (n *nodePool) ScaleDown(downscaleReplicas values.Replicas) (*metrics.SavedResources, bool, error) {
+	savedResources := metrics.NewSavedResources(0, 0)
+
+	annotations := n.GetAnnotations()
+	if _, ok := annotations[annotationOriginalLimits]; ok {
+		slog.Debug("workload is already at target scale down state, skipping", "workload", n.GetName())
+		return savedResources, false, nil
+	}
+
+	limits, err := n.getLimits()
+	if err != nil {
+		return savedResources, false, err
+	}
+
+	downscaleLimits, err := getDownscaleLimits(limits, downscaleReplicas)
+	if err != nil {
+		return savedResources, false, err
+	}
+
+	originalLimits, err := json.Marshal(limits)
+	if err != nil {
+		return savedResources, false, fmt.Errorf("failed to marshal original limits: %w", err)
+	}
+
+	err = n.setLimits(downscaleLimits)
+	if err != nil {
+		return savedResources, false, err
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[annotationOriginalLimits] = string(originalLimits)
+	n.SetAnnotations(annotations)
+
+	return savedResources, true, nil
+}
+
+// Copy creates a deep copy of the workload.
+func (n *nodePool) Copy() (Workload, error) {
+	if n.Object == nil {
+		return nil, newNilUnderlyingObjectError(n.GetKind())
+	}
+
+	return &nodePool{Unstructured: n.DeepCopy()}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (n *nodePool) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	nodePoolCopy, ok := workloadCopy.(*nodePool)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*nodePool)(nil), workloadCopy)
+	}
+
+	if n.Object == nil || nodePoolCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(n.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(n.Object, nodePoolCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", n.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (n *nodePool) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, nodePoolGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Name: n.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", n.GetKind(), n.GetName(), err)
+	}
+
+	n.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (n *nodePool) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, n.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", n.GetKind(), n.GetName(), err)
+	}
+
+	return nil
+}
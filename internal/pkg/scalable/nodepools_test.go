@@ -0,0 +1,136 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestNodePool builds a nodePool with the given spec.limits. Pass nil to omit spec.limits entirely.
+func newTestNodePool(limits map[string]any) *nodePool {
+	spec := map[string]any{}
+	if limits != nil {
+		spec["limits"] = limits
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name": "test-nodepool",
+		},
+		"spec": spec,
+	}}
+	u.SetGroupVersionKind(nodePoolGVK)
+
+	return &nodePool{Unstructured: u}
+}
+
+func TestNodePool_ScaleDownScaleUp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		limits            map[string]any
+		downscaleReplicas values.Replicas
+		wantLimits        map[string]any
+		wantErr           bool
+	}{
+		{
+			name:              "absolute zero",
+			limits:            map[string]any{"cpu": "100", "memory": "400Gi", "nvidia.com/gpu": "2"},
+			downscaleReplicas: values.AbsoluteReplicas(0),
+			wantLimits:        map[string]any{"cpu": "0", "memory": "0", "nvidia.com/gpu": "2"},
+		},
+		{
+			name:              "absolute zero without limits",
+			limits:            nil,
+			downscaleReplicas: values.AbsoluteReplicas(0),
+			wantLimits:        map[string]any{"cpu": "0", "memory": "0"},
+		},
+		{
+			name:              "percentage",
+			limits:            map[string]any{"cpu": "100", "memory": "400Gi"},
+			downscaleReplicas: values.PercentageReplicas(25),
+			wantLimits:        map[string]any{"cpu": "25", "memory": "100Gi"},
+		},
+		{
+			name:              "percentage keeps unset limits",
+			limits:            map[string]any{"cpu": "10"},
+			downscaleReplicas: values.PercentageReplicas(50),
+			wantLimits:        map[string]any{"cpu": "5"},
+		},
+		{
+			name:              "absolute non-zero is unsupported",
+			limits:            map[string]any{"cpu": "100"},
+			downscaleReplicas: values.AbsoluteReplicas(2),
+			wantErr:           true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := newTestNodePool(test.limits)
+			original := pool.DeepCopy()
+
+			_, updated, err := pool.ScaleDown(test.downscaleReplicas)
+			if test.wantErr {
+				require.Error(t, err)
+				assert.False(t, updated)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, updated)
+
+			limits, _, err := unstructured.NestedMap(pool.Object, "spec", "limits")
+			require.NoError(t, err)
+			assert.Equal(t, test.wantLimits, limits)
+			assert.Contains(t, pool.GetAnnotations(), annotationOriginalLimits)
+
+			_, updated, err = pool.ScaleDown(test.downscaleReplicas)
+			require.NoError(t, err)
+			assert.False(t, updated)
+
+			updated, err = pool.ScaleUp()
+			require.NoError(t, err)
+			assert.True(t, updated)
+			assert.NotContains(t, pool.GetAnnotations(), annotationOriginalLimits)
+
+			originalLimits, originalFound, err := unstructured.NestedMap(original.Object, "spec", "limits")
+			require.NoError(t, err)
+
+			restoredLimits, restoredFound, err := unstructured.NestedMap(pool.Object, "spec", "limits")
+			require.NoError(t, err)
+			assert.Equal(t, originalFound, restoredFound)
+
+			for resourceName, value := range originalLimits {
+				assert.Equal(t, value, restoredLimits[resourceName])
+			}
+		})
+	}
+}
+
+func TestNodePool_RoundTripWithoutLimits(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestNodePool(nil)
+
+	_, updated, err := pool.ScaleDown(values.AbsoluteReplicas(0))
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, "{}", pool.GetAnnotations()[annotationOriginalLimits])
+
+	updated, err = pool.ScaleUp()
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	spec, found, err := unstructured.NestedMap(pool.Object, "spec")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.NotContains(t, spec, "limits")
+}
@@ -0,0 +1,80 @@
+package scalable
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+)
+
+const annotationOriginalPrefix = "downscaler/original-"
+
+// isOriginalStateAnnotation checks if the annotation is used by the downscaler to store the original state of a workload.
+func isOriginalStateAnnotation(annotation string) bool {
+	return strings.HasPrefix(annotation, annotationOriginalPrefix) || annotation == annotationDownscaleRecord
+}
+
+// GetOriginalState gets the annotations storing the original state of a downscaled workload.
+// It returns nil if the workload isn't downscaled.
+func GetOriginalState(workload Workload) map[string]string {
+	var state map[string]string
+
+	for annotation, value := range workload.GetAnnotations() {
+		if !isOriginalStateAnnotation(annotation) {
+			continue
+		}
+
+		if state == nil {
+			state = make(map[string]string)
+		}
+
+		state[annotation] = value
+	}
+
+	return state
+}
+
+// RestoreOriginalState sets the annotations of the original state which are missing on the workload,
+// so it can be upscaled afterwards. Annotations still present on the workload are kept.
+// It returns false if all annotations were already present.
+func RestoreOriginalState(workload Workload, state map[string]string) bool {
+	annotations := maps.Clone(workload.GetAnnotations())
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	restored := false
+
+	for annotation, value := range state {
+		if !isOriginalStateAnnotation(annotation) {
+			continue
+		}
+
+		if _, ok := annotations[annotation]; ok {
+			continue
+		}
+
+		annotations[annotation] = value
+		restored = true
+	}
+
+	if restored {
+		workload.SetAnnotations(annotations)
+	}
+
+	return restored
+}
+
+// GetOriginalReplicas gets the original replicas stored on a downscaled workload.
+// It returns false if the workload doesn't store any original replicas.
+func GetOriginalReplicas(workload Workload) (string, bool) {
+	originalReplicas, ok := workload.GetAnnotations()[annotationOriginalReplicas]
+
+	return originalReplicas, ok
+}
+
+// StoreOriginalReplicas replaces the original replicas stored on a downscaled workload,
+// so it is upscaled to them at the end of the downtime.
+func StoreOriginalReplicas(workload Workload, replicas values.Replicas) {
+	setOriginalReplicas(replicas, workload)
+}
@@ -0,0 +1,53 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetOriginalState(t *testing.T) {
+	t.Parallel()
+
+	workload := &replicaScaledWorkload{&deployment{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			annotationOriginalReplicas: "5",
+			annotationDownscaleRecord:  `{"originalReplicas":5,"downscaledReplicas":0}`,
+			"downscaler/downtime":      "always",
+		},
+	}}}}
+
+	assert.Equal(t, map[string]string{
+		annotationOriginalReplicas: "5",
+		annotationDownscaleRecord:  `{"originalReplicas":5,"downscaledReplicas":0}`,
+	}, GetOriginalState(workload))
+
+	upscaled := &replicaScaledWorkload{&deployment{&appsv1.Deployment{}}}
+	assert.Nil(t, GetOriginalState(upscaled))
+}
+
+func TestRestoreOriginalState(t *testing.T) {
+	t.Parallel()
+
+	workload := &replicaScaledWorkload{&deployment{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			annotationOriginalReplicas: "3",
+		},
+	}}}}
+	_ = workload.setReplicas(0)
+
+	restored := RestoreOriginalState(workload, map[string]string{
+		annotationOriginalReplicas: "5",
+		annotationDownscaleRecord:  `{"originalReplicas":5,"downscaledReplicas":0}`,
+		"downscaler/downtime":      "always",
+	})
+	assert.True(t, restored)
+	assert.Equal(t, map[string]string{
+		annotationOriginalReplicas: "3",
+		annotationDownscaleRecord:  `{"originalReplicas":5,"downscaledReplicas":0}`,
+	}, workload.GetAnnotations())
+
+	assert.False(t, RestoreOriginalState(workload, map[string]string{annotationOriginalReplicas: "5"}))
+}
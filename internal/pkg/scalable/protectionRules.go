@@ -0,0 +1,73 @@
+package scalable
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
+)
+
+// ProtectionRules defines rules protecting workloads from ever being scaled by the downscaler.
+type ProtectionRules struct {
+	// LabelSelectors protects workloads whose labels match any of the selectors
+	LabelSelectors util.LabelSelectorList
+	// OwnerKinds protects workloads which are owned by a resource of any of the kinds
+	OwnerKinds []string
+	// PriorityClasses protects workloads whose pods use a matching priority class
+	PriorityClasses util.RegexList
+}
+
+// NewProtectionRules creates new protection rules from the given runtime configuration.
+func NewProtectionRules(config *util.CommonRuntimeConfiguration) *ProtectionRules {
+	return &ProtectionRules{
+		LabelSelectors:  config.ExcludeLabelSelectors,
+		OwnerKinds:      config.ExcludeOwnerKinds,
+		PriorityClasses: config.ExcludePriorityClasses,
+	}
+}
+
+// getMatchingRule gets the name of the rule protecting the workload. Empty if the workload isn't protected.
+func (p *ProtectionRules) getMatchingRule(workload Workload) string {
+	if p == nil {
+		return ""
+	}
+
+	if p.LabelSelectors.CheckMatchesAny(workload.GetLabels()) {
+		return "label selector"
+	}
+
+	if isOwnedByKind(workload, p.OwnerKinds) {
+		return "owner kind"
+	}
+
+	if isUsingPriorityClass(workload, p.PriorityClasses) {
+		return "priority class"
+	}
+
+	return ""
+}
+
+// isOwnedByKind checks if any of the owners of the workload is of one of the given kinds.
+func isOwnedByKind(workload Workload, kinds []string) bool {
+	for _, ownerReference := range workload.GetOwnerReferences() {
+		if slices.ContainsFunc(kinds, func(kind string) bool { return strings.EqualFold(kind, ownerReference.Kind) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUsingPriorityClass checks if the pods of the workload use a priority class matching any of the given priority classes.
+func isUsingPriorityClass(workload Workload, priorityClasses util.RegexList) bool {
+	if priorityClasses == nil {
+		return false
+	}
+
+	podTemplate := getPodTemplate(workload)
+	if podTemplate == nil || podTemplate.Spec.PriorityClassName == "" {
+		return false
+	}
+
+	return priorityClasses.CheckMatchesAny(podTemplate.Spec.PriorityClassName)
+}
@@ -0,0 +1,113 @@
+package scalable
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProtectionRulesGetMatchingRule(t *testing.T) {
+	t.Parallel()
+
+	var labelSelectors util.LabelSelectorList
+	require.NoError(t, labelSelectors.Set("tier=platform; app in (ingress,dns),!canary"))
+
+	rules := &ProtectionRules{
+		LabelSelectors:  labelSelectors,
+		OwnerKinds:      []string{"HelmRelease"},
+		PriorityClasses: util.RegexList{regexp.MustCompile("^system-.*-critical$"), regexp.MustCompile("^platform-critical$")},
+	}
+
+	tests := []struct {
+		name     string
+		rules    *ProtectionRules
+		workload Workload
+		want     string
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "platform"}},
+			}}},
+			want: "",
+		},
+		{
+			name:  "matching label selector",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "platform"}},
+			}}},
+			want: "label selector",
+		},
+		{
+			name:  "matching second label selector",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "dns"}},
+			}}},
+			want: "label selector",
+		},
+		{
+			name:  "label selector excluding canary",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "dns", "canary": "true"}},
+			}}},
+			want: "",
+		},
+		{
+			name:  "matching owner kind",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "helmrelease", Name: "release"}}},
+			}}},
+			want: "owner kind",
+		},
+		{
+			name:  "matching priority class",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{PriorityClassName: "system-cluster-critical"},
+				}},
+			}}},
+			want: "priority class",
+		},
+		{
+			name:  "matching priority class of cronjob",
+			rules: rules,
+			workload: &suspendScaledWorkload{&cronJob{CronJob: &batch.CronJob{
+				Spec: batch.CronJobSpec{JobTemplate: batch.JobTemplateSpec{Spec: batch.JobSpec{Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{PriorityClassName: "platform-critical"},
+				}}}},
+			}}},
+			want: "priority class",
+		},
+		{
+			name:  "not matching priority class",
+			rules: rules,
+			workload: &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{PriorityClassName: "platform-critical-batch"},
+				}},
+			}}},
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, test.rules.getMatchingRule(test.workload))
+		})
+	}
+}
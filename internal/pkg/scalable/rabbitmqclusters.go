@@ -0,0 +1,162 @@
+//nolint:dupl // necessary to handle different workload types separately
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var rabbitmqClusterGVK = schema.GroupVersionKind{
+	Group: "rabbitmq.com", Version: "v1beta1", Kind: "RabbitmqCluster",
+}
+
+// rabbitmqCluster wraps an unstructured RabbitmqCluster CR. The unstructured approach
+// is used to avoid depending on the rabbitmq cluster operator repository for a single CRD.
+type rabbitmqCluster struct {
+	*unstructured.Unstructured
+}
+
+// getRabbitmqClusters is the getResourceFunc for RabbitmqCluster.
+func getRabbitmqClusters(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   rabbitmqClusterGVK.Group,
+		Version: rabbitmqClusterGVK.Version,
+		Kind:    rabbitmqClusterGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("rabbitmq CRD not found in cluster, skipping", "kind", rabbitmqClusterGVK.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get rabbitmqclusters: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, rabbitmqClusterGVK)
+		results = append(results, &replicaScaledWorkload{&rabbitmqCluster{&item}})
+	}
+
+	return results, nil
+}
+
+// parseRabbitmqClusterFromBytes parses the admission review and returns the rabbitmqcluster wrapped in a Workload.
+func parseRabbitmqClusterFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode rabbitmqcluster: %w", err)
+	}
+
+	return &replicaScaledWorkload{&rabbitmqCluster{&u}}, nil
+}
+
+// getReplicas gets the current amount of replicas of the resource.
+func (r *rabbitmqCluster) getReplicas() (values.Replicas, error) {
+	val, found, err := unstructured.NestedFieldNoCopy(r.Object, "spec", "replicas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec.replicas for %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	if !found {
+		// the rabbitmq cluster operator defaults spec.replicas to 1
+		return values.AbsoluteReplicas(1), nil
+	}
+
+	replicas, ok := unstructuredReplicasToInt32(val)
+	if !ok {
+		return nil, newUnexpectedReplicasTypeError(val, r.GetKind(), r.GetNamespace(), r.GetName())
+	}
+
+	return values.AbsoluteReplicas(replicas), nil
+}
+
+// setReplicas sets the amount of replicas on the resource.
+func (r *rabbitmqCluster) setReplicas(replicas int32) error {
+	if err := unstructured.SetNestedField(r.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return fmt.Errorf("failed to set spec.replicas for %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	return nil
+}
+
+// getSavedResourcesRequests calculates the total saved resources requests when downscaling the RabbitmqCluster.
+func (r *rabbitmqCluster) getSavedResourcesRequests(diffReplicas int32) *metrics.SavedResources {
+	return getUnstructuredSavedResources(r.Object, diffReplicas, "spec", "resources")
+}
+
+// Copy creates a deep copy of the workload.
+func (r *rabbitmqCluster) Copy() (Workload, error) {
+	if r.Object == nil {
+		return nil, newNilUnderlyingObjectError(r.GetKind())
+	}
+
+	return &replicaScaledWorkload{
+		replicaScaledResource: &rabbitmqCluster{
+			Unstructured: r.DeepCopy(),
+		},
+	}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (r *rabbitmqCluster) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	rswCopy, ok := workloadCopy.(*replicaScaledWorkload)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*replicaScaledWorkload)(nil), workloadCopy)
+	}
+
+	rabbitmqCopy, ok := rswCopy.replicaScaledResource.(*rabbitmqCluster)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*rabbitmqCluster)(nil), rswCopy.replicaScaledResource)
+	}
+
+	if r.Object == nil || rabbitmqCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(r.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(r.Object, rabbitmqCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", r.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (r *rabbitmqCluster) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, rabbitmqClusterGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: r.GetNamespace(), Name: r.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	r.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (r *rabbitmqCluster) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, r.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestRabbitmqCluster builds a rabbitmqCluster with spec.replicas set to the given raw value.
+// Pass nil to omit spec.replicas entirely.
+func newTestRabbitmqCluster(replicasVal any) *rabbitmqCluster {
+	obj := map[string]any{
+		"metadata": map[string]any{
+			"name":      "test-rabbitmqcluster",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"resources": map[string]any{
+				"requests": map[string]any{
+					"cpu":    "500m",
+					"memory": "1Gi",
+				},
+			},
+		},
+	}
+
+	if replicasVal != nil {
+		obj["spec"].(map[string]any)["replicas"] = replicasVal
+	}
+
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(rabbitmqClusterGVK)
+
+	return &rabbitmqCluster{Unstructured: u}
+}
+
+func TestRabbitmqCluster_GetReplicas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		replicasVal  any
+		wantReplicas values.Replicas
+		wantErr      bool
+	}{
+		{
+			name:         "float64 replicas (API-server JSON)",
+			replicasVal:  float64(3),
+			wantReplicas: values.AbsoluteReplicas(3),
+		},
+		{
+			name:         "absent spec.replicas defaults to 1",
+			replicasVal:  nil,
+			wantReplicas: values.AbsoluteReplicas(1),
+		},
+		{
+			name:        "string replicas",
+			replicasVal: "3",
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newTestRabbitmqCluster(test.replicasVal)
+
+			got, err := w.getReplicas()
+
+			if test.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantReplicas, got)
+		})
+	}
+}
+
+func TestRabbitmqCluster_ScaleDown(t *testing.T) {
+	t.Parallel()
+
+	workload := &replicaScaledWorkload{newTestRabbitmqCluster(float64(3))}
+
+	savedResources, updated, err := workload.ScaleDown(values.AbsoluteReplicas(0))
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.InDelta(t, 1.5, savedResources.TotalCPU(), 0.001)
+	assert.InDelta(t, 3*1024*1024*1024, savedResources.TotalMemory(), 1)
+
+	replicas, err := workload.getReplicas()
+	require.NoError(t, err)
+	assert.Equal(t, values.AbsoluteReplicas(0), replicas)
+	assert.Equal(t, "3", workload.GetAnnotations()[annotationOriginalReplicas])
+}
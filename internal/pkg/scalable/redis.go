@@ -0,0 +1,199 @@
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	redisOperatorGroup   = "redis.redis.opstreelabs.in"
+	redisOperatorVersion = "v1beta2"
+
+	// minRedisClusterSize is the smallest clusterSize the redis operator accepts for a RedisCluster,
+	// since a cluster needs at least 3 leaders.
+	minRedisClusterSize = 3
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var redisClusterGVK = schema.GroupVersionKind{
+	Group: redisOperatorGroup, Version: redisOperatorVersion, Kind: "RedisCluster",
+}
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var redisReplicationGVK = schema.GroupVersionKind{
+	Group: redisOperatorGroup, Version: redisOperatorVersion, Kind: "RedisReplication",
+}
+
+// redis wraps an unstructured RedisCluster or RedisReplication CR of the redis operator. Both are scaled by their
+// spec.clusterSize. The unstructured approach is used to avoid depending on the redis operator repository.
+type redis struct {
+	*unstructured.Unstructured
+}
+
+// getRedisClusters is the getResourceFunc for RedisCluster.
+func getRedisClusters(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	return getRedisResources(namespace, redisClusterGVK, clientsets, ctx)
+}
+
+// getRedisReplications is the getResourceFunc for RedisReplication.
+func getRedisReplications(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	return getRedisResources(namespace, redisReplicationGVK, clientsets, ctx)
+}
+
+// getRedisResources gets all redis operator resources of the given GroupVersionKind as Workloads.
+func getRedisResources(namespace string, gvk schema.GroupVersionKind, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("redis operator CRD not found in cluster, skipping", "kind", gvk.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get %s: %w", gvk.Kind, err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, gvk)
+		results = append(results, &replicaScaledWorkload{&redis{&item}})
+	}
+
+	return results, nil
+}
+
+// parseRedisFromBytes parses the admission review and returns the redis resource wrapped in a Workload.
+func parseRedisFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode redis resource: %w", err)
+	}
+
+	return &replicaScaledWorkload{&redis{&u}}, nil
+}
+
+// getPodsPerReplica gets the amount of pods running for each replica of the cluster size.
+// A RedisCluster runs a leader and a follower for each replica.
+func (r *redis) getPodsPerReplica() int32 {
+	if r.GetKind() == redisClusterGVK.Kind {
+		return 2
+	}
+
+	return 1
+}
+
+// getReplicas gets the current amount of replicas of the resource.
+func (r *redis) getReplicas() (values.Replicas, error) {
+	val, found, err := unstructured.NestedFieldNoCopy(r.Object, "spec", "clusterSize")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec.clusterSize for %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	if !found {
+		return nil, newNoReplicasError(r.GetKind(), r.GetName())
+	}
+
+	replicas, ok := unstructuredReplicasToInt32(val)
+	if !ok {
+		return nil, newUnexpectedReplicasTypeError(val, r.GetKind(), r.GetNamespace(), r.GetName())
+	}
+
+	return values.AbsoluteReplicas(replicas), nil
+}
+
+// setReplicas sets the amount of replicas on the resource.
+// A RedisCluster can't be scaled below the minimum cluster size of the redis operator.
+func (r *redis) setReplicas(replicas int32) error {
+	if r.GetKind() == redisClusterGVK.Kind && replicas < minRedisClusterSize {
+		return newRedisClusterSizeError(r.GetNamespace(), r.GetName(), replicas)
+	}
+
+	if err := unstructured.SetNestedField(r.Object, int64(replicas), "spec", "clusterSize"); err != nil {
+		return fmt.Errorf("failed to set spec.clusterSize for %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	return nil
+}
+
+// getSavedResourcesRequests calculates the total saved resources requests when downscaling the redis resource.
+func (r *redis) getSavedResourcesRequests(diffReplicas int32) *metrics.SavedResources {
+	return getUnstructuredSavedResources(r.Object, diffReplicas*r.getPodsPerReplica(), "spec", "kubernetesConfig", "resources")
+}
+
+// Copy creates a deep copy of the workload.
+func (r *redis) Copy() (Workload, error) {
+	if r.Object == nil {
+		return nil, newNilUnderlyingObjectError(r.GetKind())
+	}
+
+	return &replicaScaledWorkload{
+		replicaScaledResource: &redis{
+			Unstructured: r.DeepCopy(),
+		},
+	}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (r *redis) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	rswCopy, ok := workloadCopy.(*replicaScaledWorkload)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*replicaScaledWorkload)(nil), workloadCopy)
+	}
+
+	redisCopy, ok := rswCopy.replicaScaledResource.(*redis)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*redis)(nil), rswCopy.replicaScaledResource)
+	}
+
+	if r.Object == nil || redisCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(r.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(r.Object, redisCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", r.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (r *redis) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, r.GroupVersionKind())
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: r.GetNamespace(), Name: r.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	r.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (r *redis) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, r.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", r.GetKind(), r.GetNamespace(), r.GetName(), err)
+	}
+
+	return nil
+}
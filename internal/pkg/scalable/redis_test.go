@@ -0,0 +1,127 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestRedis builds a redis resource of the given kind with the given spec.clusterSize and a cpu request of 1.
+func newTestRedis(kind string, clusterSize int64) *replicaScaledWorkload {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "test-redis", "namespace": "default"},
+		"spec": map[string]any{
+			"clusterSize": clusterSize,
+			"kubernetesConfig": map[string]any{
+				"resources": map[string]any{
+					"requests": map[string]any{"cpu": int64(1)},
+				},
+			},
+		},
+	}}
+	u.SetGroupVersionKind(redisClusterGVK.GroupVersion().WithKind(kind))
+
+	return &replicaScaledWorkload{&redis{u}}
+}
+
+func TestRedis_GetSavedResourcesRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		kind              string
+		clusterSize       int64
+		downscaleReplicas values.Replicas
+		wantCPU           float64
+	}{
+		{
+			name:              "redis cluster runs leaders and followers",
+			kind:              redisClusterGVK.Kind,
+			clusterSize:       5,
+			downscaleReplicas: values.AbsoluteReplicas(3),
+			wantCPU:           4,
+		},
+		{
+			name:              "redis replication",
+			kind:              redisReplicationGVK.Kind,
+			clusterSize:       3,
+			downscaleReplicas: values.AbsoluteReplicas(1),
+			wantCPU:           2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			workload := newTestRedis(test.kind, test.clusterSize)
+
+			savedResources, updated, err := workload.ScaleDown(test.downscaleReplicas)
+			require.NoError(t, err)
+			assert.True(t, updated)
+			assert.InDelta(t, test.wantCPU, savedResources.TotalCPU(), 0.001)
+			assert.Zero(t, savedResources.TotalMemory())
+		})
+	}
+}
+
+func TestRedis_ScaleDownBelowMinimumClusterSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		kind              string
+		downscaleReplicas values.Replicas
+		wantErr           bool
+	}{
+		{
+			name:              "redis cluster can't be scaled to zero",
+			kind:              redisClusterGVK.Kind,
+			downscaleReplicas: values.AbsoluteReplicas(0),
+			wantErr:           true,
+		},
+		{
+			name:              "redis cluster can't be scaled below 3 leaders",
+			kind:              redisClusterGVK.Kind,
+			downscaleReplicas: values.AbsoluteReplicas(2),
+			wantErr:           true,
+		},
+		{
+			name:              "redis cluster can be scaled to 3 leaders",
+			kind:              redisClusterGVK.Kind,
+			downscaleReplicas: values.AbsoluteReplicas(3),
+		},
+		{
+			name:              "redis replication can be scaled to zero",
+			kind:              redisReplicationGVK.Kind,
+			downscaleReplicas: values.AbsoluteReplicas(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			workload := newTestRedis(test.kind, 6)
+
+			_, updated, err := workload.ScaleDown(test.downscaleReplicas)
+			if test.wantErr {
+				var clusterSizeErr *RedisClusterSizeError
+				require.ErrorAs(t, err, &clusterSizeErr)
+				assert.False(t, updated)
+
+				clusterSize, err := workload.getReplicas()
+				require.NoError(t, err)
+				assert.Equal(t, values.AbsoluteReplicas(6), clusterSize, "the cluster size shouldn't change")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, updated)
+		})
+	}
+}
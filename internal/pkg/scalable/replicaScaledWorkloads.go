@@ -58,6 +58,7 @@ func (r *replicaScaledWorkload) ScaleUp() (bool, error) {
 	}
 
 	removeOriginalReplicas(r)
+	removeDownscaleRecord(r)
 
 	return true, nil
 }
@@ -115,6 +116,7 @@ func (r *replicaScaledWorkload) ScaleDown(downscaleReplicas values.Replicas) (*m
 	savedResources = r.getSavedResourcesRequests(currentReplicasInt32 - downscaleReplicasInt32)
 
 	setOriginalReplicas(currentReplicas, r)
+	setDownscaleRecord(&downscaleRecord{OriginalReplicas: currentReplicasInt32, DownscaledReplicas: downscaleReplicasInt32}, r)
 
 	return savedResources, true, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	"github.com/wI2L/jsondiff"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,11 @@ type rollout struct {
 	*argov1alpha1.Rollout
 }
 
+// getPodTemplate gets the template of the pods managed by the Rollout.
+func (r *rollout) getPodTemplate() *corev1.PodTemplateSpec {
+	return &r.Spec.Template
+}
+
 // setReplicas sets the amount of replicas on the resource. Changes won't be made on Kubernetes until update() is called.
 func (r *rollout) setReplicas(replicas int32) error {
 	r.Spec.Replicas = &replicas
@@ -0,0 +1,295 @@
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	sparkOperatorGroup   = "sparkoperator.k8s.io"
+	sparkOperatorVersion = "v1beta2"
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var scheduledSparkApplicationGVK = schema.GroupVersionKind{
+	Group: sparkOperatorGroup, Version: sparkOperatorVersion, Kind: "ScheduledSparkApplication",
+}
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var sparkApplicationGVK = schema.GroupVersionKind{
+	Group: sparkOperatorGroup, Version: sparkOperatorVersion, Kind: "SparkApplication",
+}
+
+//nolint:gochecknoglobals // application states of the spark operator in which the application isn't running anymore
+var sparkApplicationTerminalStates = []string{"COMPLETED", "FAILED", "SUBMISSION_FAILED"}
+
+// scheduledSparkApplication wraps an unstructured ScheduledSparkApplication CR. The unstructured approach
+// is used to avoid depending on the spark operator repository.
+type scheduledSparkApplication struct {
+	*unstructured.Unstructured
+}
+
+// getScheduledSparkApplications is the getResourceFunc for ScheduledSparkApplication.
+func getScheduledSparkApplications(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   scheduledSparkApplicationGVK.Group,
+		Version: scheduledSparkApplicationGVK.Version,
+		Kind:    scheduledSparkApplicationGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("spark operator CRD not found in cluster, skipping", "kind", scheduledSparkApplicationGVK.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get scheduledsparkapplications: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, scheduledSparkApplicationGVK)
+		results = append(results, &suspendScaledWorkload{&scheduledSparkApplication{&item}})
+	}
+
+	return results, nil
+}
+
+// parseScheduledSparkApplicationFromBytes parses the admission review and returns the scheduledsparkapplication wrapped in a Workload.
+func parseScheduledSparkApplicationFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduledsparkapplication: %w", err)
+	}
+
+	return &suspendScaledWorkload{&scheduledSparkApplication{&u}}, nil
+}
+
+// GetChildren gets the running SparkApplications created by the ScheduledSparkApplication.
+func (s *scheduledSparkApplication) GetChildren(ctx context.Context, clientsets *Clientsets) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   sparkApplicationGVK.Group,
+		Version: sparkApplicationGVK.Version,
+		Kind:    sparkApplicationGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(s.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to get sparkapplications: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+
+	for i := range list.Items {
+		item := list.Items[i]
+
+		if !slices.ContainsFunc(item.GetOwnerReferences(), func(ownerReference metav1.OwnerReference) bool {
+			return ownerReference.UID == s.GetUID()
+		}) {
+			continue
+		}
+
+		setGroupVersionKindIfEmpty(&item, sparkApplicationGVK)
+
+		application := &sparkApplication{Unstructured: &item}
+		if !application.isRunning() {
+			continue
+		}
+
+		results = append(results, application)
+	}
+
+	return results, nil
+}
+
+// getSuspend gets the current value of spec.suspend on the scheduledSparkApplication and the target downscale state for it.
+//
+//nolint:nonamedreturns // named return values are used to document the returned values
+func (s *scheduledSparkApplication) getSuspend() (currentValue, targetDownscaleState values.Replicas) {
+	current, _, _ := unstructured.NestedBool(s.Object, "spec", "suspend")
+
+	return values.BooleanReplicas(current), values.BooleanReplicas(true)
+}
+
+// setSuspend sets the value of spec.suspend on the scheduledSparkApplication.
+func (s *scheduledSparkApplication) setSuspend(suspend bool) {
+	err := unstructured.SetNestedField(s.Object, suspend, "spec", "suspend")
+	if err != nil {
+		slog.Error("failed to set spec.suspend", "workload", s.GetName(), "namespace", s.GetNamespace(), "error", err)
+	}
+}
+
+// getSavedResourcesRequests returns the saved CPU and memory requests.
+// Spark applications define their resources in spark specific units, so they are not calculated.
+func (s *scheduledSparkApplication) getSavedResourcesRequests() *metrics.SavedResources {
+	return metrics.NewSavedResources(0, 0)
+}
+
+// Copy creates a deep copy of the workload.
+func (s *scheduledSparkApplication) Copy() (Workload, error) {
+	if s.Object == nil {
+		return nil, newNilUnderlyingObjectError(s.GetKind())
+	}
+
+	return &suspendScaledWorkload{
+		suspendScaledResource: &scheduledSparkApplication{
+			Unstructured: s.DeepCopy(),
+		},
+	}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (s *scheduledSparkApplication) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	sswCopy, ok := workloadCopy.(*suspendScaledWorkload)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*suspendScaledWorkload)(nil), workloadCopy)
+	}
+
+	scheduledCopy, ok := sswCopy.suspendScaledResource.(*scheduledSparkApplication)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*scheduledSparkApplication)(nil), sswCopy.suspendScaledResource)
+	}
+
+	if s.Object == nil || scheduledCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(s.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(s.Object, scheduledCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", s.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (s *scheduledSparkApplication) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, scheduledSparkApplicationGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: s.GetNamespace(), Name: s.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", s.GetKind(), s.GetNamespace(), s.GetName(), err)
+	}
+
+	s.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (s *scheduledSparkApplication) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, s.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", s.GetKind(), s.GetNamespace(), s.GetName(), err)
+	}
+
+	return nil
+}
+
+// sparkApplication wraps an unstructured SparkApplication CR created by a ScheduledSparkApplication.
+// Running applications can't be paused, so they are terminated when scaled down.
+type sparkApplication struct {
+	*unstructured.Unstructured
+	terminate bool
+}
+
+// isRunning checks if the application hasn't reached a terminal state yet.
+func (s *sparkApplication) isRunning() bool {
+	state, _, _ := unstructured.NestedString(s.Object, "status", "applicationState", "state")
+
+	return !slices.Contains(sparkApplicationTerminalStates, state)
+}
+
+// ScaleUp scales the resource up. Terminated applications are not restored, the next run is created by the schedule.
+func (s *sparkApplication) ScaleUp() (bool, error) {
+	return false, nil
+}
+
+// ScaleDown scales the resource down by marking it to be terminated on update.
+func (s *sparkApplication) ScaleDown(_ values.Replicas) (*metrics.SavedResources, bool, error) {
+	if !s.isRunning() {
+		slog.Debug("spark application is not running, skipping", "workload", s.GetName(), "namespace", s.GetNamespace())
+		return metrics.NewSavedResources(0, 0), false, nil
+	}
+
+	s.terminate = true
+
+	return metrics.NewSavedResources(0, 0), true, nil
+}
+
+// Copy creates a deep copy of the workload.
+func (s *sparkApplication) Copy() (Workload, error) {
+	if s.Object == nil {
+		return nil, newNilUnderlyingObjectError(s.GetKind())
+	}
+
+	return &sparkApplication{Unstructured: s.DeepCopy(), terminate: s.terminate}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (s *sparkApplication) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	applicationCopy, ok := workloadCopy.(*sparkApplication)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*sparkApplication)(nil), workloadCopy)
+	}
+
+	if s.Object == nil || applicationCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(s.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(s.Object, applicationCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", s.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (s *sparkApplication) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, sparkApplicationGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: s.GetNamespace(), Name: s.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", s.GetKind(), s.GetNamespace(), s.GetName(), err)
+	}
+
+	s.Unstructured = fresh
+
+	return nil
+}
+
+// Update deletes the application if it was marked to be terminated, otherwise it updates it with all changes made to it.
+func (s *sparkApplication) Update(clientsets *Clientsets, ctx context.Context) error {
+	if s.terminate {
+		err := clientsets.Client.Delete(ctx, s.Unstructured)
+		if err != nil {
+			return fmt.Errorf("failed to terminate %s %s/%s: %w", s.GetKind(), s.GetNamespace(), s.GetName(), err)
+		}
+
+		return nil
+	}
+
+	err := clientsets.Client.Update(ctx, s.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", s.GetKind(), s.GetNamespace(), s.GetName(), err)
+	}
+
+	return nil
+}
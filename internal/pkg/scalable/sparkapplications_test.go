@@ -0,0 +1,90 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestScheduledSparkApplication_ScaleDownScaleUp(t *testing.T) {
+	t.Parallel()
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "test-scheduled", "namespace": "default"},
+		"spec":     map[string]any{"schedule": "@every 1h"},
+	}}
+	u.SetGroupVersionKind(scheduledSparkApplicationGVK)
+
+	workload := &suspendScaledWorkload{&scheduledSparkApplication{u}}
+
+	_, updated, err := workload.ScaleDown(values.AbsoluteReplicas(0))
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	suspend, _, err := unstructured.NestedBool(u.Object, "spec", "suspend")
+	require.NoError(t, err)
+	assert.True(t, suspend)
+	assert.Equal(t, "false", workload.GetAnnotations()[annotationOriginalReplicas])
+
+	updated, err = workload.ScaleUp()
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	suspend, _, err = unstructured.NestedBool(u.Object, "spec", "suspend")
+	require.NoError(t, err)
+	assert.False(t, suspend)
+	assert.NotContains(t, workload.GetAnnotations(), annotationOriginalReplicas)
+
+	_, isParent := GetParentWorkload(workload)
+	assert.True(t, isParent)
+}
+
+func TestSparkApplication_ScaleDown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		state         string
+		wantTerminate bool
+	}{
+		{
+			name:          "running application is terminated",
+			state:         "RUNNING",
+			wantTerminate: true,
+		},
+		{
+			name:          "submitted application is terminated",
+			state:         "",
+			wantTerminate: true,
+		},
+		{
+			name:          "completed application is left alone",
+			state:         "COMPLETED",
+			wantTerminate: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"name": "test-application", "namespace": "default"},
+				"status": map[string]any{
+					"applicationState": map[string]any{"state": test.state},
+				},
+			}}
+			u.SetGroupVersionKind(sparkApplicationGVK)
+
+			application := &sparkApplication{Unstructured: u}
+
+			_, updated, err := application.ScaleDown(values.AbsoluteReplicas(0))
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTerminate, updated)
+			assert.Equal(t, test.wantTerminate, application.terminate)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
 	"github.com/wI2L/jsondiff"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,11 @@ type statefulSet struct {
 	*appsv1.StatefulSet
 }
 
+// getPodTemplate gets the template of the pods managed by the StatefulSet.
+func (s *statefulSet) getPodTemplate() *corev1.PodTemplateSpec {
+	return &s.Spec.Template
+}
+
 // setReplicas sets the amount of replicas on the resource. Changes won't be made on Kubernetes until update() is called.
 func (s *statefulSet) setReplicas(replicas int32) error {
 	s.Spec.Replicas = &replicas
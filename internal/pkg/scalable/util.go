@@ -10,8 +10,12 @@ import (
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -22,12 +26,13 @@ const (
 	kafkaStrimziVersion                 = "v1"
 )
 
-// FilterExcluded filters the workloads to match the includeLabels, excludedNamespaces and excludedWorkloads.
+// FilterExcluded filters the workloads to match the includeLabels, excludedNamespaces, excludedWorkloads and protectionRules.
 func FilterExcluded(
 	workloads []Workload,
 	includeLabels,
 	excludedNamespaces,
 	excludedWorkloads util.RegexList,
+	protectionRules *ProtectionRules,
 	currentNamespaceToMetrics map[string]*metrics.NamespaceMetricsHolder,
 ) []Workload {
 	externallyScaled := getExternallyScaled(workloads)
@@ -76,6 +81,18 @@ func FilterExcluded(
 			continue
 		}
 
+		if rule := protectionRules.getMatchingRule(workload); rule != "" {
+			slog.Debug(
+				"the workload is protected, excluding it from being scanned",
+				"workload", workload.GetName(),
+				"namespace", workload.GetNamespace(),
+				"rule", rule,
+			)
+			currentNamespaceToMetrics[workload.GetNamespace()].IncrementExcludedWorkloadsCount()
+
+			continue
+		}
+
 		if isExternallyScaled(workload, externallyScaled) {
 			slog.Debug(
 				"the workload is scaled externally, excluding it from being scanned",
@@ -230,6 +247,63 @@ func getWorkloadAsScaledObject(workload Workload) *scaledObject {
 	return scaledObject
 }
 
+// PairWorkloads removes all workloads which are paired to another workload in the list (e.g. vertical pod autoscalers)
+// and returns them separately, mapped to the uid of the workload they target.
+func PairWorkloads(workloads []Workload) ([]Workload, map[types.UID][]Workload) {
+	results := make([]Workload, 0, len(workloads))
+	pairedWorkloads := make(map[types.UID][]Workload)
+
+	for _, workload := range workloads {
+		target := getPairedTarget(workload, workloads)
+		if target == nil {
+			results = append(results, workload)
+			continue
+		}
+
+		slog.Debug(
+			"workload is paired to another workload, it will follow the scaling of its target",
+			"workload", workload.GetName(),
+			"namespace", workload.GetNamespace(),
+			"target", target.GetName(),
+		)
+
+		pairedWorkloads[target.GetUID()] = append(pairedWorkloads[target.GetUID()], workload)
+	}
+
+	return slices.Clip(results), pairedWorkloads
+}
+
+// getPairedTarget gets the workload the given workload is paired to. nil if it isn't paired to any of the workloads.
+//
+//nolint:ireturn // this function should return an interface type
+func getPairedTarget(workload Workload, workloads []Workload) Workload {
+	paired, ok := workload.(pairedWorkload)
+	if !ok {
+		return nil
+	}
+
+	targetIdentifier := paired.getTargetIdentifier()
+	if targetIdentifier == nil {
+		return nil
+	}
+
+	for _, target := range workloads {
+		if target == workload {
+			continue
+		}
+
+		if _, isPaired := target.(pairedWorkload); isPaired {
+			continue
+		}
+
+		if matchesWorkloadIdentifier(targetIdentifier, target.GetName(), target.GetNamespace(), target.GroupVersionKind()) {
+			return target
+		}
+	}
+
+	return nil
+}
+
 // isMatchingLabels check if the workload is matching any of the specified labels.
 func isMatchingLabels(workload Workload, includeLabels util.RegexList) bool {
 	if includeLabels == nil {
@@ -357,3 +431,31 @@ func unstructuredReplicasToInt32(val any) (int32, bool) {
 		return 0, false
 	}
 }
+
+// getUnstructuredSavedResources calculates the saved resources of an unstructured resource using the
+// resource requirements found at the given fields, multiplied by the amount of pods which are removed.
+func getUnstructuredSavedResources(object map[string]any, diffPods int32, fields ...string) *metrics.SavedResources {
+	requests, found, err := unstructured.NestedMap(object, append(fields, "requests")...)
+	if err != nil || !found {
+		return metrics.NewSavedResources(0, 0)
+	}
+
+	var totalSavedCPU, totalSavedMemory float64
+
+	if cpu, ok := requests[string(corev1.ResourceCPU)]; ok {
+		if quantity, err := resource.ParseQuantity(fmt.Sprint(cpu)); err == nil {
+			totalSavedCPU = quantity.AsApproximateFloat64()
+		}
+	}
+
+	if memory, ok := requests[string(corev1.ResourceMemory)]; ok {
+		if quantity, err := resource.ParseQuantity(fmt.Sprint(memory)); err == nil {
+			totalSavedMemory = quantity.AsApproximateFloat64()
+		}
+	}
+
+	totalSavedCPU *= float64(diffPods)
+	totalSavedMemory *= float64(diffPods)
+
+	return metrics.NewSavedResources(totalSavedCPU, totalSavedMemory)
+}
@@ -11,6 +11,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -199,6 +200,7 @@ func TestFilterExcluded(t *testing.T) { //nolint: maintidx// fine to read and un
 		includeLabels             util.RegexList
 		excludedNamespaces        util.RegexList
 		excludedWorkloads         util.RegexList
+		protectionRules           *ProtectionRules
 		currentNamespaceToMetrics map[string]*metrics.NamespaceMetricsHolder
 		want                      []Workload
 	}{
@@ -315,6 +317,20 @@ func TestFilterExcluded(t *testing.T) { //nolint: maintidx// fine to read and un
 			},
 			want: []Workload{ns3.deployment1, ns3.scaledObject, ns1.deployment1, ns3.job2},
 		},
+		{
+			name:               "exclude protected",
+			workloads:          []Workload{ns1.deployment1, ns1.deployment2, ns1.labeledDeployment},
+			includeLabels:      nil,
+			excludedNamespaces: nil,
+			excludedWorkloads:  nil,
+			protectionRules: &ProtectionRules{
+				LabelSelectors: util.LabelSelectorList{labels.SelectorFromSet(labels.Set{"label": "value"})},
+			},
+			currentNamespaceToMetrics: map[string]*metrics.NamespaceMetricsHolder{
+				"Namespace1": {},
+			},
+			want: []Workload{ns1.deployment1, ns1.deployment2},
+		},
 		{
 			name:               "exclude scaled object target when workload typemeta is empty",
 			workloads:          []Workload{ns6.deployment1, ns6.scaledObject},
@@ -337,6 +353,7 @@ func TestFilterExcluded(t *testing.T) { //nolint: maintidx// fine to read and un
 				test.includeLabels,
 				test.excludedNamespaces,
 				test.excludedWorkloads,
+				test.protectionRules,
 				test.currentNamespaceToMetrics,
 			)
 
@@ -0,0 +1,222 @@
+package scalable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/wI2L/jsondiff"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	annotationOriginalUpdateMode = "downscaler/original-update-mode"
+	vpaUpdateModeOff             = "Off"
+)
+
+//nolint:gochecknoglobals // package-level GVK required for unstructured client
+var verticalPodAutoscalerGVK = schema.GroupVersionKind{
+	Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler",
+}
+
+// verticalPodAutoscaler wraps an unstructured VerticalPodAutoscaler CR. The unstructured approach
+// is used to avoid depending on the autoscaler repository for a single CRD.
+type verticalPodAutoscaler struct {
+	*unstructured.Unstructured
+}
+
+// getVerticalPodAutoscalers is the getResourceFunc for VerticalPodAutoscalers.
+func getVerticalPodAutoscalers(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   verticalPodAutoscalerGVK.Group,
+		Version: verticalPodAutoscalerGVK.Version,
+		Kind:    verticalPodAutoscalerGVK.Kind + "List",
+	})
+
+	if err := clientsets.Client.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			slog.Warn("vertical pod autoscaler CRD not found in cluster, skipping", "kind", verticalPodAutoscalerGVK.Kind, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get verticalpodautoscalers: %w", err)
+	}
+
+	results := make([]Workload, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		setGroupVersionKindIfEmpty(&item, verticalPodAutoscalerGVK)
+		results = append(results, &verticalPodAutoscaler{&item})
+	}
+
+	return results, nil
+}
+
+// parseVerticalPodAutoscalerFromBytes parses the admission review and returns the verticalpodautoscaler wrapped in a Workload.
+func parseVerticalPodAutoscalerFromBytes(rawObject []byte) (Workload, error) {
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(rawObject, &u); err != nil {
+		return nil, fmt.Errorf("failed to decode verticalpodautoscaler: %w", err)
+	}
+
+	return &verticalPodAutoscaler{&u}, nil
+}
+
+// getUpdateMode gets the current spec.updatePolicy.updateMode. An empty string means the field is unset.
+func (v *verticalPodAutoscaler) getUpdateMode() (string, error) {
+	updateMode, _, err := unstructured.NestedString(v.Object, "spec", "updatePolicy", "updateMode")
+	if err != nil {
+		return "", fmt.Errorf("failed to get spec.updatePolicy.updateMode for %s %s/%s: %w", v.GetKind(), v.GetNamespace(), v.GetName(), err)
+	}
+
+	return updateMode, nil
+}
+
+// setUpdateMode sets spec.updatePolicy.updateMode. An empty string removes the field to restore the VPA default.
+func (v *verticalPodAutoscaler) setUpdateMode(updateMode string) error {
+	if updateMode == "" {
+		unstructured.RemoveNestedField(v.Object, "spec", "updatePolicy", "updateMode")
+		return nil
+	}
+
+	err := unstructured.SetNestedField(v.Object, updateMode, "spec", "updatePolicy", "updateMode")
+	if err != nil {
+		return fmt.Errorf("failed to set spec.updatePolicy.updateMode for %s %s/%s: %w", v.GetKind(), v.GetNamespace(), v.GetName(), err)
+	}
+
+	return nil
+}
+
+// getTargetIdentifier returns the identifier of the workload referenced in spec.targetRef, or nil if it is not set.
+func (v *verticalPodAutoscaler) getTargetIdentifier() *workloadIdentifier {
+	name, _, _ := unstructured.NestedString(v.Object, "spec", "targetRef", "name")
+	if name == "" {
+		return nil
+	}
+
+	apiVersion, _, _ := unstructured.NestedString(v.Object, "spec", "targetRef", "apiVersion")
+	kind, _, _ := unstructured.NestedString(v.Object, "spec", "targetRef", "kind")
+
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		slog.Warn("invalid apiVersion in targetRef, ignoring target", "workload", v.GetName(), "namespace", v.GetNamespace(), "error", err)
+		return nil
+	}
+
+	return &workloadIdentifier{
+		gvk:       groupVersion.WithKind(kind),
+		name:      name,
+		namespace: v.GetNamespace(),
+	}
+}
+
+// ScaleUp scales the resource up.
+func (v *verticalPodAutoscaler) ScaleUp() (bool, error) {
+	annotations := v.GetAnnotations()
+
+	originalUpdateMode, ok := annotations[annotationOriginalUpdateMode]
+	if !ok {
+		slog.Debug("original update mode is not set, skipping", "workload", v.GetName(), "namespace", v.GetNamespace())
+		return false, nil
+	}
+
+	err := v.setUpdateMode(originalUpdateMode)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore original update mode for workload: %w", err)
+	}
+
+	delete(annotations, annotationOriginalUpdateMode)
+	v.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+// ScaleDown scales the resource down.
+func (v *verticalPodAutoscaler) ScaleDown(_ values.Replicas) (*metrics.SavedResources, bool, error) {
+	savedResources := metrics.NewSavedResources(0, 0)
+
+	currentUpdateMode, err := v.getUpdateMode()
+	if err != nil {
+		return savedResources, false, err
+	}
+
+	if currentUpdateMode == vpaUpdateModeOff {
+		slog.Debug("workload is already at target scale down state, skipping", "workload", v.GetName(), "namespace", v.GetNamespace())
+		return savedResources, false, nil
+	}
+
+	err = v.setUpdateMode(vpaUpdateModeOff)
+	if err != nil {
+		return savedResources, false, err
+	}
+
+	annotations := v.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[annotationOriginalUpdateMode] = currentUpdateMode
+	v.SetAnnotations(annotations)
+
+	return savedResources, true, nil
+}
+
+// Copy creates a deep copy of the workload.
+func (v *verticalPodAutoscaler) Copy() (Workload, error) {
+	if v.Object == nil {
+		return nil, newNilUnderlyingObjectError(v.GetKind())
+	}
+
+	return &verticalPodAutoscaler{Unstructured: v.DeepCopy()}, nil
+}
+
+// Compare compares the workload with another workload and returns the differences as a jsondiff.Patch.
+func (v *verticalPodAutoscaler) Compare(workloadCopy Workload) (jsondiff.Patch, error) {
+	vpaCopy, ok := workloadCopy.(*verticalPodAutoscaler)
+	if !ok {
+		return nil, newExpectTypeGotTypeError((*verticalPodAutoscaler)(nil), workloadCopy)
+	}
+
+	if v.Object == nil || vpaCopy.Object == nil {
+		return nil, newNilUnderlyingObjectError(v.GetKind())
+	}
+
+	diff, err := jsondiff.Compare(v.Object, vpaCopy.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s: %w", v.GetKind(), err)
+	}
+
+	return diff, nil
+}
+
+// Reget regets the workload to ensure the latest state.
+func (v *verticalPodAutoscaler) Reget(clientsets *Clientsets, ctx context.Context) error {
+	fresh := &unstructured.Unstructured{}
+	setGroupVersionKindIfEmpty(fresh, verticalPodAutoscalerGVK)
+
+	err := clientsets.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: v.GetNamespace(), Name: v.GetName()}, fresh)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", v.GetKind(), v.GetNamespace(), v.GetName(), err)
+	}
+
+	v.Unstructured = fresh
+
+	return nil
+}
+
+// Update updates the resource with all changes made to it.
+func (v *verticalPodAutoscaler) Update(clientsets *Clientsets, ctx context.Context) error {
+	err := clientsets.Client.Update(ctx, v.Unstructured)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", v.GetKind(), v.GetNamespace(), v.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestVerticalPodAutoscaler builds a verticalPodAutoscaler targeting the given deployment name with the given update mode.
+// Pass an empty update mode to omit spec.updatePolicy.updateMode entirely.
+func newTestVerticalPodAutoscaler(targetName, updateMode string, annotations map[string]string) *verticalPodAutoscaler {
+	spec := map[string]any{
+		"targetRef": map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       targetName,
+		},
+	}
+
+	if updateMode != "" {
+		spec["updatePolicy"] = map[string]any{"updateMode": updateMode}
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      "test-vpa",
+			"namespace": "default",
+		},
+		"spec": spec,
+	}}
+	u.SetGroupVersionKind(verticalPodAutoscalerGVK)
+	u.SetAnnotations(annotations)
+
+	return &verticalPodAutoscaler{Unstructured: u}
+}
+
+func TestVerticalPodAutoscaler_ScaleDown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		updateMode             string
+		wantUpdateMode         string
+		wantOriginalUpdateMode string
+		wantUpdated            bool
+	}{
+		{
+			name:                   "scale down from Auto",
+			updateMode:             "Auto",
+			wantUpdateMode:         vpaUpdateModeOff,
+			wantOriginalUpdateMode: "Auto",
+			wantUpdated:            true,
+		},
+		{
+			name:                   "scale down with unset update mode",
+			updateMode:             "",
+			wantUpdateMode:         vpaUpdateModeOff,
+			wantOriginalUpdateMode: "",
+			wantUpdated:            true,
+		},
+		{
+			name:                   "already off",
+			updateMode:             vpaUpdateModeOff,
+			wantUpdateMode:         vpaUpdateModeOff,
+			wantOriginalUpdateMode: "",
+			wantUpdated:            false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			vpa := newTestVerticalPodAutoscaler("test-deployment", test.updateMode, nil)
+
+			_, updated, err := vpa.ScaleDown(values.AbsoluteReplicas(0))
+			require.NoError(t, err)
+			assert.Equal(t, test.wantUpdated, updated)
+
+			updateMode, err := vpa.getUpdateMode()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantUpdateMode, updateMode)
+
+			originalUpdateMode, ok := vpa.GetAnnotations()[annotationOriginalUpdateMode]
+			assert.Equal(t, test.wantUpdated, ok)
+			assert.Equal(t, test.wantOriginalUpdateMode, originalUpdateMode)
+		})
+	}
+}
+
+func TestVerticalPodAutoscaler_ScaleUp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		wantUpdateMode string
+		wantUpdated    bool
+	}{
+		{
+			name:           "restore original update mode",
+			annotations:    map[string]string{annotationOriginalUpdateMode: "Recreate"},
+			wantUpdateMode: "Recreate",
+			wantUpdated:    true,
+		},
+		{
+			name:           "restore unset update mode",
+			annotations:    map[string]string{annotationOriginalUpdateMode: ""},
+			wantUpdateMode: "",
+			wantUpdated:    true,
+		},
+		{
+			name:           "original update mode not set",
+			annotations:    nil,
+			wantUpdateMode: vpaUpdateModeOff,
+			wantUpdated:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			vpa := newTestVerticalPodAutoscaler("test-deployment", vpaUpdateModeOff, test.annotations)
+
+			updated, err := vpa.ScaleUp()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantUpdated, updated)
+
+			updateMode, err := vpa.getUpdateMode()
+			require.NoError(t, err)
+			assert.Equal(t, test.wantUpdateMode, updateMode)
+			assert.NotContains(t, vpa.GetAnnotations(), annotationOriginalUpdateMode)
+		})
+	}
+}
+
+func TestPairWorkloads(t *testing.T) {
+	t.Parallel()
+
+	target := &replicaScaledWorkload{&deployment{Deployment: &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+	}}}
+	pairedVPA := newTestVerticalPodAutoscaler("test-deployment", "Auto", nil)
+	unpairedVPA := newTestVerticalPodAutoscaler("other-deployment", "Auto", nil)
+
+	workloads, paired := PairWorkloads([]Workload{target, pairedVPA, unpairedVPA})
+
+	assert.Equal(t, []Workload{target, unpairedVPA}, workloads)
+	assert.Equal(t, []Workload{pairedVPA}, paired[target.GetUID()])
+}
@@ -3,6 +3,8 @@ package scalable
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	argo "github.com/argoproj/argo-rollouts/pkg/client/clientset/versioned"
 	"github.com/caas-team/gokubedownscaler/internal/pkg/metrics"
@@ -11,6 +13,7 @@ import (
 	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
 	"github.com/wI2L/jsondiff"
 	zalando "github.com/zalando-incubator/stackset-controller/pkg/clientset"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -21,26 +24,43 @@ import (
 // getResourceFunc is a function that gets a specific resource as a Workload.
 type getResourceFunc func(namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error)
 
+// getResourceFuncs gets the getResourceFunc of every supported resource.
+func getResourceFuncs() map[string]getResourceFunc {
+	return map[string]getResourceFunc{
+		"deployments":                getDeployments,
+		"statefulsets":               getStatefulSets,
+		"cronjobs":                   getCronJobs,
+		"jobs":                       getJobs,
+		"daemonsets":                 getDaemonSets,
+		"poddisruptionbudgets":       getPodDisruptionBudgets,
+		"horizontalpodautoscalers":   getHorizontalPodAutoscalers,
+		"scaledobjects":              getScaledObjects,
+		"rollouts":                   getRollouts,
+		"stacks":                     getStacks,
+		"prometheuses":               getPrometheuses,
+		"autoscalingrunnersets":      getAutoscalingRunnerSets,
+		"postgresqls":                getPostgresqls,
+		"kafkaconnects":              getKafkaConnects,
+		"kafkamirrormaker2s":         getKafkaMirrorMaker2s,
+		"kafkabridges":               getKafkaBridges,
+		"verticalpodautoscalers":     getVerticalPodAutoscalers,
+		"nodepools":                  getNodePools,
+		"rabbitmqclusters":           getRabbitmqClusters,
+		"redisclusters":              getRedisClusters,
+		"redisreplications":          getRedisReplications,
+		"eventlisteners":             getEventListeners,
+		"scheduledsparkapplications": getScheduledSparkApplications,
+	}
+}
+
+// SupportedResources gets the names of all supported resources in alphabetical order.
+func SupportedResources() []string {
+	return slices.Sorted(maps.Keys(getResourceFuncs()))
+}
+
 // GetWorkloads gets all workloads of the given resource in the cluster.
 func GetWorkloads(resource, namespace string, clientsets *Clientsets, ctx context.Context) ([]Workload, error) {
-	resourceFuncMap := map[string]getResourceFunc{
-		"deployments":              getDeployments,
-		"statefulsets":             getStatefulSets,
-		"cronjobs":                 getCronJobs,
-		"jobs":                     getJobs,
-		"daemonsets":               getDaemonSets,
-		"poddisruptionbudgets":     getPodDisruptionBudgets,
-		"horizontalpodautoscalers": getHorizontalPodAutoscalers,
-		"scaledobjects":            getScaledObjects,
-		"rollouts":                 getRollouts,
-		"stacks":                   getStacks,
-		"prometheuses":             getPrometheuses,
-		"autoscalingrunnersets":    getAutoscalingRunnerSets,
-		"postgresqls":              getPostgresqls,
-		"kafkaconnects":            getKafkaConnects,
-		"kafkamirrormaker2s":       getKafkaMirrorMaker2s,
-		"kafkabridges":             getKafkaBridges,
-	}
+	resourceFuncMap := getResourceFuncs()
 
 	resourceFunc, exists := resourceFuncMap[resource]
 	if !exists {
@@ -55,6 +75,13 @@ func GetWorkloads(resource, namespace string, clientsets *Clientsets, ctx contex
 	return workloads, nil
 }
 
+// IsClusterScoped checks if the given resource is cluster scoped and therefore isn't fetched per namespace.
+func IsClusterScoped(resource string) bool {
+	clusterScopedResources := []string{"nodepools"}
+
+	return slices.Contains(clusterScopedResources, resource)
+}
+
 // parseWorkloadFunc is a function that parses a specific admission review as a Workload.
 type parseWorkloadFunc func(rawObject []byte) (Workload, error)
 
@@ -63,22 +90,29 @@ type parseWorkloadFunc func(rawObject []byte) (Workload, error)
 //nolint:ireturn // this function should return an interface type
 func ParseWorkloadFromRawObject(resource string, rawObject []byte) (Workload, error) {
 	parseWorkloadFuncMap := map[string]parseWorkloadFunc{
-		"deployment":              parseDeploymentFromBytes,
-		"statefulset":             parseStatefulSetFromBytes,
-		"cronjob":                 parseCronJobFromBytes,
-		"job":                     parseJobFromBytes,
-		"daemonset":               parseDaemonSetFromBytes,
-		"poddisruptionbudget":     parsePodDisruptionBudgetFromBytes,
-		"horizontalpodautoscaler": parseHorizontalPodAutoscalerFromBytes,
-		"scaledobject":            parseScaledObjectFromBytes,
-		"rollout":                 parseRolloutFromBytes,
-		"stack":                   parseStackFromBytes,
-		"prometheus":              parsePrometheusFromBytes,
-		"autoscalingrunnerset":    parseAutoscalingRunnerSetFromBytes,
-		"postgresql":              parsePostgresqlFromBytes,
-		"kafkaconnect":            parseKafkaConnectFromBytes,
-		"kafkamirrormaker2":       parseKafkaMirrorMaker2FromBytes,
-		"kafkabridge":             parseKafkaBridgeFromBytes,
+		"deployment":                parseDeploymentFromBytes,
+		"statefulset":               parseStatefulSetFromBytes,
+		"cronjob":                   parseCronJobFromBytes,
+		"job":                       parseJobFromBytes,
+		"daemonset":                 parseDaemonSetFromBytes,
+		"poddisruptionbudget":       parsePodDisruptionBudgetFromBytes,
+		"horizontalpodautoscaler":   parseHorizontalPodAutoscalerFromBytes,
+		"scaledobject":              parseScaledObjectFromBytes,
+		"rollout":                   parseRolloutFromBytes,
+		"stack":                     parseStackFromBytes,
+		"prometheus":                parsePrometheusFromBytes,
+		"autoscalingrunnerset":      parseAutoscalingRunnerSetFromBytes,
+		"postgresql":                parsePostgresqlFromBytes,
+		"kafkaconnect":              parseKafkaConnectFromBytes,
+		"kafkamirrormaker2":         parseKafkaMirrorMaker2FromBytes,
+		"kafkabridge":               parseKafkaBridgeFromBytes,
+		"verticalpodautoscaler":     parseVerticalPodAutoscalerFromBytes,
+		"nodepool":                  parseNodePoolFromBytes,
+		"rabbitmqcluster":           parseRabbitmqClusterFromBytes,
+		"rediscluster":              parseRedisFromBytes,
+		"redisreplication":          parseRedisFromBytes,
+		"eventlistener":             parseEventListenerFromBytes,
+		"scheduledsparkapplication": parseScheduledSparkApplicationFromBytes,
 	}
 
 	parseFunc, exists := parseWorkloadFuncMap[resource]
@@ -98,10 +132,67 @@ type ParentWorkload interface {
 	GetChildren(ctx context.Context, clientsets *Clientsets) ([]Workload, error)
 }
 
+// GetParentWorkload gets the workload as a ParentWorkload. Suspend scaled workloads are unwrapped,
+// as the wrapper hides the children of CronJobs and ScheduledSparkApplications.
+func GetParentWorkload(workload Workload) (ParentWorkload, bool) {
+	if wrapper, ok := workload.(*suspendScaledWorkload); ok {
+		parent, ok := wrapper.suspendScaledResource.(ParentWorkload)
+
+		return parent, ok
+	}
+
+	parent, ok := workload.(ParentWorkload)
+
+	return parent, ok
+}
+
+// podTemplateResource is a resource which manages pods created from a pod template.
+type podTemplateResource interface {
+	// getPodTemplate gets the template of the pods managed by the resource
+	getPodTemplate() *corev1.PodTemplateSpec
+}
+
+// getPodTemplate gets the template of the pods managed by the workload.
+// It returns nil if the workload doesn't manage pods through a pod template.
+func getPodTemplate(workload Workload) *corev1.PodTemplateSpec {
+	var resource any = workload
+
+	switch wrapper := workload.(type) {
+	case *replicaScaledWorkload:
+		resource = wrapper.replicaScaledResource
+	case *suspendScaledWorkload:
+		resource = wrapper.suspendScaledResource
+	}
+
+	podTemplate, ok := resource.(podTemplateResource)
+	if !ok {
+		return nil
+	}
+
+	return podTemplate.getPodTemplate()
+}
+
+// GetPodTemplateLabels gets the labels of the pods managed by the workload.
+// It returns nil if the workload doesn't manage pods through a pod template.
+func GetPodTemplateLabels(workload Workload) map[string]string {
+	podTemplate := getPodTemplate(workload)
+	if podTemplate == nil {
+		return nil
+	}
+
+	return podTemplate.Labels
+}
+
 type PercentageWorkload interface {
 	AllowPercentageReplicas() bool
 }
 
+// pairedWorkload is a workload which follows the scaling of the workload it targets.
+type pairedWorkload interface {
+	// getTargetIdentifier gets the identifier of the targeted workload, nil if it doesn't target any workload
+	getTargetIdentifier() *workloadIdentifier
+}
+
 // scalableResource provides all functions needed to scale any type of resource.
 type scalableResource interface {
 	// GetAnnotations gets the annotations of the resource
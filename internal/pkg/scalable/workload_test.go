@@ -0,0 +1,48 @@
+package scalable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetParentWorkload(t *testing.T) {
+	t.Parallel()
+
+	scheduledSpark := &unstructured.Unstructured{Object: map[string]any{}}
+	scheduledSpark.SetGroupVersionKind(scheduledSparkApplicationGVK)
+
+	tests := []struct {
+		name         string
+		workload     Workload
+		wantIsParent bool
+	}{
+		{
+			name:         "scheduled spark application",
+			workload:     &suspendScaledWorkload{&scheduledSparkApplication{scheduledSpark}},
+			wantIsParent: true,
+		},
+		{
+			name:         "cronjob",
+			workload:     &suspendScaledWorkload{&cronJob{&batch.CronJob{}}},
+			wantIsParent: true,
+		},
+		{
+			name:         "deployment",
+			workload:     &replicaScaledWorkload{&deployment{&appsv1.Deployment{}}},
+			wantIsParent: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, isParent := GetParentWorkload(test.workload)
+			assert.Equal(t, test.wantIsParent, isParent)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/scalable"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName      = "github.com/caas-team/gokubedownscaler"
+	shutdownTimeout = 5 * time.Second
+)
+
+// ShutdownFunc flushes all pending spans and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup sets the global tracer provider to export spans via OTLP/gRPC to the endpoint.
+// If the endpoint is empty, tracing stays disabled and the returned shutdown function does nothing.
+func Setup(endpoint string, insecure bool, serviceName string, ctx context.Context) (ShutdownFunc, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	serviceResource, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	slog.Info("exporting traces", "endpoint", endpoint)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a new span as a child of the span in the context.
+//
+//nolint:spancheck // the span is ended by the caller
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error on the span, if there is one, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		RecordError(span, err)
+	}
+
+	span.End()
+}
+
+// RecordError records the error on the span and marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// WorkloadAttributes gets the attributes identifying the workload.
+func WorkloadAttributes(workload scalable.Workload) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("workload.kind", workload.GroupVersionKind().Kind),
+		attribute.String("workload.namespace", workload.GetNamespace()),
+		attribute.String("workload.name", workload.GetName()),
+	}
+}
+
+// Shutdown flushes all pending spans and logs if it fails.
+func Shutdown(shutdown ShutdownFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := shutdown(ctx)
+	if err != nil {
+		slog.Error("failed to shut down tracing", "error", err)
+	}
+}
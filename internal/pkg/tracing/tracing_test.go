@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+		expectedEvents int
+	}{
+		{
+			name:           "without error",
+			err:            nil,
+			expectedStatus: codes.Unset,
+			expectedEvents: 0,
+		},
+		{
+			name:           "with error",
+			err:            errors.New("failed"),
+			expectedStatus: codes.Error,
+			expectedEvents: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			_, span := provider.Tracer(tracerName).Start(t.Context(), "test")
+			End(span, test.err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, test.expectedStatus, spans[0].Status().Code)
+			assert.Len(t, spans[0].Events(), test.expectedEvents)
+		})
+	}
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	t.Parallel()
+
+	shutdown, err := Setup("", false, "test", t.Context())
+	require.NoError(t, err)
+	require.NoError(t, shutdown(t.Context()))
+}
@@ -20,6 +20,12 @@ type CommonRuntimeConfiguration struct {
 	ExcludeNamespaces RegexList
 	// ExcludeWorkloads sets the list of workload names to ignore while downscaling.
 	ExcludeWorkloads RegexList
+	// ExcludeLabelSelectors sets the list of label selectors protecting matching workloads from being scaled.
+	ExcludeLabelSelectors LabelSelectorList
+	// ExcludeOwnerKinds sets the list of owner kinds protecting owned workloads from being scaled.
+	ExcludeOwnerKinds []string
+	// ExcludePriorityClasses sets the list of pod priority classes protecting workloads from being scaled.
+	ExcludePriorityClasses RegexList
 	// IncludeLabels sets the list of labels workloads have to match one of to be scaled.
 	IncludeLabels RegexList
 	// TimeAnnotation sets the annotation used for grace-period instead of creation time.
@@ -34,21 +40,39 @@ type CommonRuntimeConfiguration struct {
 	Burst int
 	// Kubeconfig sets an optional kubeconfig to use for testing purposes instead of the in-cluster config.
 	Kubeconfig string
+	// AuditLog sets where an audit record of every mutation is written to ("stdout", "stderr" or a file path). Disabled if empty.
+	AuditLog string
+	// AuditLogMaxSize sets the size in megabytes after which the audit log file is rotated.
+	AuditLogMaxSize int
+	// AuditLogMaxBackups sets how many rotated audit log files are kept.
+	AuditLogMaxBackups int
+	// TracingEndpoint sets the OTLP/gRPC endpoint spans are exported to. Disabled if empty.
+	TracingEndpoint string
+	// TracingInsecure sets if the connection to the tracing endpoint doesn't use TLS.
+	TracingInsecure bool
 }
 
 func GetDefaultConfig() *CommonRuntimeConfiguration {
 	return &CommonRuntimeConfiguration{
-		DryRun:            false,
-		Debug:             false,
-		IncludeNamespaces: nil,
-		IncludeResources:  []string{"deployments"},
-		ExcludeNamespaces: RegexList{regexp.MustCompile("kube-system"), regexp.MustCompile("kube-downscaler")},
-		ExcludeWorkloads:  nil,
-		IncludeLabels:     nil,
-		TimeAnnotation:    "",
-		Kubeconfig:        "",
-		MetricsEnabled:    false,
-		JsonLogs:          false,
+		DryRun:                 false,
+		Debug:                  false,
+		IncludeNamespaces:      nil,
+		IncludeResources:       []string{"deployments"},
+		ExcludeNamespaces:      RegexList{regexp.MustCompile("kube-system"), regexp.MustCompile("kube-downscaler")},
+		ExcludeWorkloads:       nil,
+		ExcludeLabelSelectors:  nil,
+		ExcludeOwnerKinds:      nil,
+		ExcludePriorityClasses: nil,
+		IncludeLabels:          nil,
+		TimeAnnotation:         "",
+		Kubeconfig:             "",
+		MetricsEnabled:         false,
+		JsonLogs:               false,
+		AuditLog:               "",
+		AuditLogMaxSize:        100, //nolint:mnd // default size of the audit log file
+		AuditLogMaxBackups:     5,   //nolint:mnd // default amount of rotated audit log files
+		TracingEndpoint:        "",
+		TracingInsecure:        false,
 	}
 }
 
@@ -85,6 +109,21 @@ func (c *CommonRuntimeConfiguration) ParseCommonFlags() {
 		"exclude-deployments",
 		"exclude deployments from being scaled (optional)",
 	)
+	flag.Var(
+		&c.ExcludeLabelSelectors,
+		"exclude-label-selectors",
+		"exclude workloads matching any of these semicolon separated label selectors from being scaled (optional)",
+	)
+	flag.Var(
+		(*StringListValue)(&c.ExcludeOwnerKinds),
+		"exclude-owner-kinds",
+		"exclude workloads owned by a resource of these kinds from being scaled (optional)",
+	)
+	flag.Var(
+		&c.ExcludePriorityClasses,
+		"exclude-priority-classes",
+		"exclude workloads whose pods use one of these priority classes from being scaled (optional)",
+	)
 	flag.Var(
 		&c.IncludeLabels,
 		"matching-labels",
@@ -120,6 +159,36 @@ func (c *CommonRuntimeConfiguration) ParseCommonFlags() {
 		false,
 		"sets logs in json format (default: false)",
 	)
+	flag.StringVar(
+		&c.AuditLog,
+		"audit-log",
+		"",
+		`write an audit record of every mutation to "stdout", "stderr" or the file at this path (default: disabled)`,
+	)
+	flag.IntVar(
+		&c.AuditLogMaxSize,
+		"audit-log-max-size",
+		100, //nolint:mnd // default size of the audit log file
+		"the size in megabytes after which the audit log file is rotated, 0 disables the rotation (default: 100)",
+	)
+	flag.IntVar(
+		&c.AuditLogMaxBackups,
+		"audit-log-max-backups",
+		5, //nolint:mnd // default amount of rotated audit log files
+		"how many rotated audit log files are kept (default: 5)",
+	)
+	flag.StringVar(
+		&c.TracingEndpoint,
+		"tracing-endpoint",
+		"",
+		"export OpenTelemetry spans via OTLP/gRPC to this endpoint, e.g. otel-collector:4317 (default: disabled)",
+	)
+	flag.BoolVar(
+		&c.TracingInsecure,
+		"tracing-insecure",
+		false,
+		"connect to the tracing endpoint without TLS (default: false)",
+	)
 	flag.StringVar(
 		&c.Kubeconfig,
 		"k",
@@ -137,5 +206,17 @@ func (c *CommonRuntimeConfiguration) ParseConfigEnvVars() error {
 		return fmt.Errorf("error while getting EXCLUDE_DEPLOYMENTS environment variable: %w", err)
 	}
 
+	if err := GetEnvValue("EXCLUDE_LABEL_SELECTORS", &c.ExcludeLabelSelectors); err != nil {
+		return fmt.Errorf("error while getting EXCLUDE_LABEL_SELECTORS environment variable: %w", err)
+	}
+
+	if err := GetEnvValue("EXCLUDE_OWNER_KINDS", (*StringListValue)(&c.ExcludeOwnerKinds)); err != nil {
+		return fmt.Errorf("error while getting EXCLUDE_OWNER_KINDS environment variable: %w", err)
+	}
+
+	if err := GetEnvValue("EXCLUDE_PRIORITY_CLASSES", &c.ExcludePriorityClasses); err != nil {
+		return fmt.Errorf("error while getting EXCLUDE_PRIORITY_CLASSES environment variable: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LabelSelectorList is a list of label selectors with a Set function for the flag package.
+// Selectors are separated by semicolons, since a single selector can already contain commas.
+type LabelSelectorList []labels.Selector
+
+func (l *LabelSelectorList) Set(text string) error {
+	entries := strings.Split(text, ";")
+	*l = make(LabelSelectorList, 0, len(entries))
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		selector, err := labels.Parse(entry)
+		if err != nil {
+			return fmt.Errorf("failed to parse label selector %q: %w", entry, err)
+		}
+
+		*l = append(*l, selector)
+	}
+
+	return nil
+}
+
+func (l *LabelSelectorList) String() string {
+	return fmt.Sprint(*l)
+}
+
+// CheckMatchesAny checks if the labels match any of the label selectors.
+func (l *LabelSelectorList) CheckMatchesAny(labelSet map[string]string) bool {
+	if l == nil {
+		return false
+	}
+
+	for _, selector := range *l {
+		if selector.Matches(labels.Set(labelSet)) {
+			return true
+		}
+	}
+
+	return false
+}
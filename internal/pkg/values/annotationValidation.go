@@ -0,0 +1,57 @@
+package values
+
+import (
+	"context"
+	"slices"
+)
+
+//nolint:gochecknoglobals // the annotations read by the scope parser
+var scopeAnnotations = []string{
+	annotationDownscalePeriod,
+	annotationDowntime,
+	annotationUpscalePeriod,
+	annotationUptime,
+	annotationExclude,
+	annotationExcludeUntil,
+	annotationWakeFor,
+	annotationWakeUntil,
+	annotationForceUptime,
+	annotationForceDowntime,
+	annotationDownscaleReplicas,
+	annotationGracePeriod,
+	annotationScaleChildren,
+	annotationExclusionUpscale,
+}
+
+// IsScopeAnnotation checks if the annotation is read by the scope parser.
+func IsScopeAnnotation(annotation string) bool {
+	return slices.Contains(scopeAnnotations, annotation)
+}
+
+// validationLogger is a resource logger remembering the invalid annotations reported by the parser instead of creating events.
+// Some invalid annotations (e.g. downscaler/upscale-excluded) are only reported by the parser without failing it.
+type validationLogger struct {
+	reasons []string
+}
+
+func (v *validationLogger) ErrorInvalidAnnotation(_, reason string, _ context.Context) {
+	v.reasons = append(v.reasons, reason)
+}
+
+func (v *validationLogger) ErrorIncompatibleFields(reason string, _ context.Context) {
+	v.reasons = append(v.reasons, reason)
+}
+
+// ValidateAnnotations parses the downscaler annotations the same way the downscaler does, without creating any events.
+// It returns the error failing the parsing and the invalid annotations which are ignored by the parser.
+func ValidateAnnotations(annotations map[string]string, ctx context.Context) ([]string, error) {
+	logger := &validationLogger{}
+
+	err := NewScope().GetScopeFromAnnotations(annotations, logger, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the parsing didn't fail, so the invalid annotations reported to the logger were ignored by the parser
+	return logger.reasons, nil
+}
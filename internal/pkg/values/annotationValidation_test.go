@@ -0,0 +1,73 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAnnotations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantIgnored string
+		wantErr     string
+	}{
+		{
+			name:        "no annotations",
+			annotations: map[string]string{},
+		},
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				"downscaler/uptime":             "Mon-Fri 07:00-19:00 Europe/Berlin",
+				"downscaler/downscale-replicas": "1",
+				"downscaler/original-replicas":  "3",
+			},
+		},
+		{
+			name:        "invalid timespan",
+			annotations: map[string]string{"downscaler/uptime": "Mon-Fri 7:00-19:00"},
+			wantErr:     `failed to parse "downscaler/uptime" annotation`,
+		},
+		{
+			name:        "ignored invalid boolean",
+			annotations: map[string]string{"downscaler/upscale-excluded": "yes please"},
+			wantIgnored: `failed to parse "downscaler/upscale-excluded" annotation`,
+		},
+		{
+			name: "incompatible fields",
+			annotations: map[string]string{
+				"downscaler/uptime":   "Mon-Fri 07:00-19:00 Europe/Berlin",
+				"downscaler/downtime": "Sat-Sun 00:00-24:00 Europe/Berlin",
+			},
+			wantErr: "found incompatible fields",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ignored, err := ValidateAnnotations(test.annotations, t.Context())
+			if test.wantErr == "" {
+				require.NoError(t, err)
+
+				if test.wantIgnored == "" {
+					assert.Empty(t, ignored)
+					return
+				}
+
+				require.Len(t, ignored, 1)
+				assert.Contains(t, ignored[0], test.wantIgnored)
+
+				return
+			}
+
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
+)
+
+// ChangeLimit represents a limit on the amount of changes, either as an absolute amount or as a percentage of a total.
+// A ChangeLimit which isn't set is never exceeded.
+type ChangeLimit struct {
+	isSet        bool
+	isPercentage bool
+	value        int
+}
+
+// Set parses an absolute amount (e.g. "10") or a percentage (e.g. "20%") as the limit.
+func (c *ChangeLimit) Set(value string) error {
+	trimmed, isPercentage := strings.CutSuffix(strings.TrimSpace(value), "%")
+
+	limit, err := strconv.Atoi(trimmed)
+	if err != nil {
+		return fmt.Errorf("failed to parse change limit: %w", err)
+	}
+
+	if limit < 0 || (isPercentage && limit > 100) {
+		return newInvalidChangeLimitError(value)
+	}
+
+	c.isSet = true
+	c.isPercentage = isPercentage
+	c.value = limit
+
+	return nil
+}
+
+func (c *ChangeLimit) String() string {
+	if !c.isSet {
+		return util.UndefinedString
+	}
+
+	if c.isPercentage {
+		return fmt.Sprintf("%d%%", c.value)
+	}
+
+	return strconv.Itoa(c.value)
+}
+
+// IsSet checks if a limit was set.
+func (c *ChangeLimit) IsSet() bool {
+	return c.isSet
+}
+
+// IsExceeded checks if the amount of changes exceeds the limit, using total as the base for percentages.
+func (c *ChangeLimit) IsExceeded(changes, total int) bool {
+	if !c.isSet {
+		return false
+	}
+
+	if c.isPercentage {
+		return changes*100 > c.value*total
+	}
+
+	return changes > c.value
+}
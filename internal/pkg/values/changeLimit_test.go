@@ -0,0 +1,123 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeLimit_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		expectErr bool
+	}{
+		{
+			name:  "absolute limit",
+			input: "10",
+			want:  "10",
+		},
+		{
+			name:  "percentage limit",
+			input: "25%",
+			want:  "25%",
+		},
+		{
+			name:      "negative limit",
+			input:     "-1",
+			expectErr: true,
+		},
+		{
+			name:      "percentage over 100",
+			input:     "120%",
+			expectErr: true,
+		},
+		{
+			name:      "invalid limit",
+			input:     "ten",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var limit ChangeLimit
+
+			err := limit.Set(test.input)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, limit.String())
+		})
+	}
+}
+
+func TestChangeLimit_IsExceeded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		limit   string
+		changes int
+		total   int
+		want    bool
+	}{
+		{
+			name:    "unset limit",
+			changes: 100,
+			total:   100,
+			want:    false,
+		},
+		{
+			name:    "absolute limit reached",
+			limit:   "5",
+			changes: 5,
+			total:   100,
+			want:    false,
+		},
+		{
+			name:    "absolute limit exceeded",
+			limit:   "5",
+			changes: 6,
+			total:   100,
+			want:    true,
+		},
+		{
+			name:    "percentage limit reached",
+			limit:   "20%",
+			changes: 2,
+			total:   10,
+			want:    false,
+		},
+		{
+			name:    "percentage limit exceeded",
+			limit:   "20%",
+			changes: 3,
+			total:   10,
+			want:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var limit ChangeLimit
+
+			if test.limit != "" {
+				require.NoError(t, limit.Set(test.limit))
+			}
+
+			assert.Equal(t, test.want, limit.IsExceeded(test.changes, test.total))
+		})
+	}
+}
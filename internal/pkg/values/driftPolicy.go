@@ -0,0 +1,24 @@
+package values
+
+// DriftPolicy defines how the downscaler handles manual changes made to a workload while it is downscaled.
+type DriftPolicy string
+
+const (
+	DriftPolicyRedownscale DriftPolicy = "redownscale" // the original replicas are kept and the workload is downscaled again
+	DriftPolicyAdopt       DriftPolicy = "adopt"       // the manually set replicas become the new original replicas
+	DriftPolicyIgnore      DriftPolicy = "ignore"      // the workload is left alone until it is upscaled
+)
+
+func (d *DriftPolicy) Set(value string) error {
+	switch policy := DriftPolicy(value); policy {
+	case DriftPolicyRedownscale, DriftPolicyAdopt, DriftPolicyIgnore:
+		*d = policy
+		return nil
+	default:
+		return newInvalidDriftPolicyError(value)
+	}
+}
+
+func (d *DriftPolicy) String() string {
+	return string(*d)
+}
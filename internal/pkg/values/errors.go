@@ -91,3 +91,45 @@ func newUndefinedDefaultError(reason string) error {
 func (u *UndefinedDefaultError) Error() string {
 	return fmt.Sprintf("undefined default value error: %q", u.reason)
 }
+
+type InvalidGlobalModeError struct {
+	value string
+}
+
+func newInvalidGlobalModeError(value string) error {
+	return &InvalidGlobalModeError{value: value}
+}
+
+func (i *InvalidGlobalModeError) Error() string {
+	return fmt.Sprintf(
+		"error: invalid global mode %q, expected one of %q, %q or %q",
+		i.value, GlobalModeResume, GlobalModePause, GlobalModeUpscale,
+	)
+}
+
+type InvalidChangeLimitError struct {
+	value string
+}
+
+func newInvalidChangeLimitError(value string) error {
+	return &InvalidChangeLimitError{value: value}
+}
+
+func (i *InvalidChangeLimitError) Error() string {
+	return fmt.Sprintf("error: invalid change limit %q, expected a positive integer or a percentage between 0%% and 100%%", i.value)
+}
+
+type InvalidDriftPolicyError struct {
+	value string
+}
+
+func newInvalidDriftPolicyError(value string) error {
+	return &InvalidDriftPolicyError{value: value}
+}
+
+func (i *InvalidDriftPolicyError) Error() string {
+	return fmt.Sprintf(
+		"error: invalid drift policy %q, expected one of %q, %q or %q",
+		i.value, DriftPolicyRedownscale, DriftPolicyAdopt, DriftPolicyIgnore,
+	)
+}
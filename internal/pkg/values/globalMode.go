@@ -0,0 +1,27 @@
+package values
+
+// GlobalMode is the mode of the global switch on the downscaler's namespace, which overrides all other scaling configuration.
+type GlobalMode string
+
+const (
+	GlobalModeResume  GlobalMode = "resume"  // the downscaler works as configured
+	GlobalModePause   GlobalMode = "pause"   // the downscaler doesn't scale or mutate any workloads
+	GlobalModeUpscale GlobalMode = "upscale" // the downscaler upscales all workloads and doesn't mutate any workloads
+)
+
+// ParseGlobalMode parses the value of the global mode annotation. An empty value is treated as resume.
+func ParseGlobalMode(value string) (GlobalMode, error) {
+	switch mode := GlobalMode(value); mode {
+	case "", GlobalModeResume:
+		return GlobalModeResume, nil
+	case GlobalModePause, GlobalModeUpscale:
+		return mode, nil
+	default:
+		return GlobalModePause, newInvalidGlobalModeError(value)
+	}
+}
+
+// IsActive returns true if the downscaler should scale and mutate workloads as configured.
+func (g GlobalMode) IsActive() bool {
+	return g == GlobalModeResume
+}
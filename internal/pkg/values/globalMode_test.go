@@ -0,0 +1,61 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGlobalMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		want      GlobalMode
+		expectErr bool
+	}{
+		{
+			name:  "unset",
+			input: "",
+			want:  GlobalModeResume,
+		},
+		{
+			name:  "resume",
+			input: "resume",
+			want:  GlobalModeResume,
+		},
+		{
+			name:  "pause",
+			input: "pause",
+			want:  GlobalModePause,
+		},
+		{
+			name:  "upscale",
+			input: "upscale",
+			want:  GlobalModeUpscale,
+		},
+		{
+			name:      "invalid value falls back to pause",
+			input:     "paused",
+			want:      GlobalModePause,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseGlobalMode(test.input)
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
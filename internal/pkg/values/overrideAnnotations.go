@@ -0,0 +1,13 @@
+package values
+
+import "time"
+
+// GetWakeRequestAnnotations gets the annotations requesting a wake up for the duration.
+func GetWakeRequestAnnotations(duration time.Duration) map[string]string {
+	return map[string]string{annotationWakeFor: duration.String()}
+}
+
+// GetExclusionAnnotations gets the annotations excluding the workloads until the time.
+func GetExclusionAnnotations(until time.Time) map[string]string {
+	return map[string]string{annotationExcludeUntil: until.UTC().Format(time.RFC3339)}
+}
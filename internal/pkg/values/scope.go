@@ -43,6 +43,18 @@ func (s ScopeID) String() string {
 	}[s]
 }
 
+// String gets the string representation of the Scaling.
+func (s Scaling) String() string {
+	return map[Scaling]string{
+		ScalingNone:       "none",
+		ScalingIgnore:     "ignore",
+		ScalingDown:       "down",
+		ScalingUp:         "up",
+		ScalingMultiple:   "multiple",
+		ScalingIncomplete: "incomplete",
+	}[s]
+}
+
 // NewScope gets a new scope with all values in an unset state.
 func NewScope() *Scope {
 	return &Scope{
@@ -59,6 +71,8 @@ type Scope struct {
 	UpTime            timeSpans       // within these timespans workloads will be scaled up, outside of them they will be scaled down
 	Exclude           timeSpans       // defines when the workload should be excluded
 	ExcludeUntil      *time.Time      // until when the workload should be excluded
+	WakeUntil         *time.Time      // until when the workload is woken up by a wake request
+	WakeRequested     bool            // the wake request is a duration which wasn't converted into an absolute time yet
 	ForceUptime       timeSpans       // force workload into an uptime state when in one of the timespans
 	ForceDowntime     timeSpans       // force workload into a downtime state when in one of the timespans
 	DownscaleReplicas Replicas        // the replicas to scale down to
@@ -77,6 +91,8 @@ func GetDefaultScope() *Scope {
 		UpTime:            nil,
 		Exclude:           nil,
 		ExcludeUntil:      nil,
+		WakeUntil:         nil,
+		WakeRequested:     false,
 		ForceUptime:       nil,
 		ForceDowntime:     nil,
 		DownscaleReplicas: AbsoluteReplicas(0),
@@ -105,9 +121,14 @@ func (s *Scope) CheckForIncompatibleFields() error {
 
 // getCurrentScaling gets the current scaling, not checking for incompatibility.
 func (s *Scope) getCurrentScaling(scopes Scopes) Scaling {
+	return s.getScalingAt(time.Now(), scopes)
+}
+
+// getScalingAt gets the scaling at the target time, not checking for incompatibility.
+func (s *Scope) getScalingAt(targetTime time.Time, scopes Scopes) Scaling {
 	// check times
 	if s.DownTime != nil {
-		inTimeSpans, err := s.DownTime.inTimeSpans(scopes)
+		inTimeSpans, err := s.DownTime.inTimeSpansAt(targetTime, scopes)
 		if err != nil {
 			return ScalingIncomplete
 		}
@@ -120,7 +141,7 @@ func (s *Scope) getCurrentScaling(scopes Scopes) Scaling {
 	}
 
 	if s.UpTime != nil {
-		inTimeSpans, err := s.UpTime.inTimeSpans(scopes)
+		inTimeSpans, err := s.UpTime.inTimeSpansAt(targetTime, scopes)
 		if err != nil {
 			return ScalingIncomplete
 		}
@@ -134,19 +155,19 @@ func (s *Scope) getCurrentScaling(scopes Scopes) Scaling {
 
 	// check periods
 	if s.DownscalePeriod != nil || s.UpscalePeriod != nil {
-		return s.getScalingFromPeriods(scopes)
+		return s.getScalingFromPeriods(targetTime, scopes)
 	}
 
 	return ScalingNone
 }
 
-func (s *Scope) getScalingFromPeriods(scopes Scopes) Scaling {
-	inDowntime, errInDowntime := s.DownscalePeriod.inTimeSpans(scopes)
+func (s *Scope) getScalingFromPeriods(targetTime time.Time, scopes Scopes) Scaling {
+	inDowntime, errInDowntime := s.DownscalePeriod.inTimeSpansAt(targetTime, scopes)
 	if errInDowntime != nil {
 		return ScalingIncomplete
 	}
 
-	inUptime, errInUptime := s.UpscalePeriod.inTimeSpans(scopes)
+	inUptime, errInUptime := s.UpscalePeriod.inTimeSpansAt(targetTime, scopes)
 	if errInUptime != nil {
 		return ScalingIncomplete
 	}
@@ -167,12 +188,20 @@ func (s *Scope) getScalingFromPeriods(scopes Scopes) Scaling {
 }
 
 func (s *Scope) getForceScaling(scopes Scopes) Scaling {
-	forceDowntime, errForceDowntime := s.ForceDowntime.inTimeSpans(scopes)
+	return s.getForceScalingAt(time.Now(), scopes)
+}
+
+func (s *Scope) getForceScalingAt(targetTime time.Time, scopes Scopes) Scaling {
+	if s.WakeUntil != nil && targetTime.Before(*s.WakeUntil) {
+		return ScalingUp // a wake request overrides the forced downtime of the same scope
+	}
+
+	forceDowntime, errForceDowntime := s.ForceDowntime.inTimeSpansAt(targetTime, scopes)
 	if errForceDowntime != nil {
 		return ScalingIncomplete
 	}
 
-	forceUptime, errForceUptime := s.ForceUptime.inTimeSpans(scopes)
+	forceUptime, errForceUptime := s.ForceUptime.inTimeSpansAt(targetTime, scopes)
 	if errForceUptime != nil {
 		return ScalingIncomplete
 	}
@@ -239,32 +268,101 @@ func (s Scopes) GetDefaultWeekdayTo() *time.Weekday {
 
 // GetCurrentScaling gets the current scaling of the first scope that implements scaling.
 func (s Scopes) GetCurrentScaling() Scaling {
+	scaling, _ := s.getScalingAt(time.Now())
+
+	return scaling
+}
+
+// GetDecidingScope gets the scope which decides the current scaling, returns false if no scope implements scaling.
+func (s Scopes) GetDecidingScope() (ScopeID, bool) {
+	scaling, scopeID := s.getScalingAt(time.Now())
+
+	return scopeID, scaling != ScalingNone
+}
+
+// GetNextScalingChange gets the next time within the limit at which the scaling changes and the scaling it changes to.
+// It returns false if the scaling doesn't change within the limit.
+func (s Scopes) GetNextScalingChange(now time.Time, limit time.Duration) (time.Time, Scaling, bool) {
+	currentScaling, _ := s.getScalingAt(now)
+	cursor := now
+
+	for {
+		boundary, ok := s.nextBoundary(cursor)
+		if !ok || boundary.Sub(now) > limit {
+			return time.Time{}, ScalingNone, false
+		}
+
+		scaling, _ := s.getScalingAt(boundary)
+		if scaling != currentScaling {
+			return boundary, scaling, true
+		}
+
+		cursor = boundary
+	}
+}
+
+// getScalingAt gets the scaling at the target time of the first scope that implements scaling and the id of that scope.
+func (s Scopes) getScalingAt(targetTime time.Time) (Scaling, ScopeID) {
 	var result Scaling
 
-	for _, scope := range s {
-		forcedScaling := scope.getForceScaling(s)
+	var resultScope ScopeID
+
+	for i, scope := range s {
+		forcedScaling := scope.getForceScalingAt(targetTime, s)
 		if forcedScaling == ScalingNone {
 			continue // scope doesnt implement forced scaling; falling through
 		}
 
 		if forcedScaling == ScalingIgnore {
 			result = ScalingIgnore // default to ScalingIgnore instead of ScalingNone for correct log message
-			break                  // break out since forced scaling is set, but just inactive
+			resultScope = ScopeID(i)
+
+			break // break out since forced scaling is set, but just inactive
 		}
 
-		return forcedScaling
+		return forcedScaling, ScopeID(i)
 	}
 
-	for _, scope := range s {
-		scopeScaling := scope.getCurrentScaling(s)
+	for i, scope := range s {
+		scopeScaling := scope.getScalingAt(targetTime, s)
 		if scopeScaling == ScalingNone {
 			continue // scope doesnt implement scaling; falling through
 		}
 
-		return scopeScaling
+		return scopeScaling, ScopeID(i)
+	}
+
+	return result, resultScope
+}
+
+// nextBoundary gets the earliest time after the given time at which any scaling timespan of the scopes could start or end.
+func (s Scopes) nextBoundary(after time.Time) (time.Time, bool) {
+	var next time.Time
+
+	found := false
+
+	for _, scope := range s {
+		for _, spans := range []timeSpans{
+			scope.ForceDowntime, scope.ForceUptime, scope.DownTime, scope.UpTime, scope.DownscalePeriod, scope.UpscalePeriod,
+		} {
+			boundary, ok := spans.nextBoundary(after, s)
+			if !ok {
+				continue
+			}
+
+			if !found || boundary.Before(next) {
+				next = boundary
+				found = true
+			}
+		}
+
+		if scope.WakeUntil != nil && scope.WakeUntil.After(after) && (!found || scope.WakeUntil.Before(next)) {
+			next = *scope.WakeUntil
+			found = true
+		}
 	}
 
-	return result
+	return next, found
 }
 
 // GetDownscaleReplicas gets the downscale replicas of the first scope that implements downscale replicas.
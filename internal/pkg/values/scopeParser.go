@@ -18,6 +18,8 @@ const (
 	annotationUptime            = "downscaler/uptime"
 	annotationExclude           = "downscaler/exclude"
 	annotationExcludeUntil      = "downscaler/exclude-until"
+	annotationWakeFor           = "downscaler/wake-for"
+	annotationWakeUntil         = "downscaler/wake-until"
 	annotationForceUptime       = "downscaler/force-uptime"
 	annotationForceDowntime     = "downscaler/force-downtime"
 	annotationDownscaleReplicas = "downscaler/downscale-replicas"
@@ -207,6 +209,10 @@ func (s *Scope) GetScopeFromAnnotations( //nolint: funlen,gocognit,cyclop,gocycl
 		s.ExcludeUntil = &excludeUntil
 	}
 
+	if err = s.parseWakeRequest(annotations, logEvent, ctx); err != nil {
+		return err
+	}
+
 	if forceUptime, ok := annotations[annotationForceUptime]; ok {
 		err = s.ForceUptime.Set(forceUptime)
 		if err != nil {
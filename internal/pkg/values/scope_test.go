@@ -850,3 +850,99 @@ func TestGetWorkloadCreationTime(t *testing.T) {
 		})
 	}
 }
+
+func TestScopes_GetNextScalingChange(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC) // friday
+
+	tests := []struct {
+		name        string
+		scopes      Scopes
+		wantTime    time.Time
+		wantScaling Scaling
+		wantFound   bool
+	}{
+		{
+			name: "end of the daily uptime",
+			scopes: Scopes{
+				&Scope{UpTime: timeSpans{relativeTimeSpan{
+					timezone: time.UTC, weekdayFrom: ptr(time.Monday), weekdayTo: ptr(time.Friday), timeFrom: ptr(8 * Hour), timeTo: ptr(18 * Hour),
+				}}},
+				&Scope{}, &Scope{}, &Scope{}, &Scope{},
+			},
+			wantTime:    time.Date(2025, time.June, 6, 18, 0, 0, 0, time.UTC),
+			wantScaling: ScalingDown,
+			wantFound:   true,
+		},
+		{
+			name: "skips boundaries which don't change the scaling",
+			scopes: Scopes{
+				&Scope{DownTime: timeSpans{relativeTimeSpan{
+					timezone: time.UTC, weekdayFrom: ptr(time.Friday), weekdayTo: ptr(time.Monday), timeFrom: ptr(0 * Hour), timeTo: ptr(24 * Hour),
+				}}},
+				&Scope{}, &Scope{}, &Scope{}, &Scope{},
+			},
+			wantTime:    time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+			wantScaling: ScalingUp,
+			wantFound:   true,
+		},
+		{
+			name: "absolute force downtime",
+			scopes: Scopes{
+				&Scope{ForceDowntime: timeSpans{absoluteTimeSpan{from: now.Add(2 * time.Hour), to: now.Add(4 * time.Hour)}}},
+				&Scope{}, &Scope{}, &Scope{UpTime: timeSpans{booleanTimeSpan(true)}}, &Scope{},
+			},
+			wantTime:    now.Add(2 * time.Hour),
+			wantScaling: ScalingDown,
+			wantFound:   true,
+		},
+		{
+			name: "end of a wake request",
+			scopes: Scopes{
+				&Scope{}, &Scope{WakeUntil: ptr(now.Add(2 * time.Hour))}, &Scope{}, &Scope{DownTime: timeSpans{booleanTimeSpan(true)}}, &Scope{},
+			},
+			wantTime:    now.Add(2 * time.Hour),
+			wantScaling: ScalingDown,
+			wantFound:   true,
+		},
+		{
+			name: "never changes",
+			scopes: Scopes{
+				&Scope{DownTime: timeSpans{booleanTimeSpan(true)}},
+				&Scope{}, &Scope{}, &Scope{}, &Scope{},
+			},
+			wantFound: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			changeTime, scaling, found := test.scopes.GetNextScalingChange(now, 8*24*time.Hour)
+			assert.Equal(t, test.wantFound, found)
+			assert.Equal(t, test.wantScaling, scaling)
+			assert.True(t, test.wantTime.Equal(changeTime), "expected %s, got %s", test.wantTime, changeTime)
+		})
+	}
+}
+
+func TestScopes_GetDecidingScope(t *testing.T) {
+	t.Parallel()
+
+	scopes := Scopes{
+		&Scope{},
+		&Scope{ForceDowntime: timeSpans{booleanTimeSpan(false)}},
+		&Scope{},
+		&Scope{DownTime: timeSpans{booleanTimeSpan(true)}},
+		&Scope{},
+	}
+
+	scopeID, found := scopes.GetDecidingScope()
+	assert.True(t, found)
+	assert.Equal(t, ScopeEnvironment, scopeID)
+
+	_, found = Scopes{&Scope{}, &Scope{}, &Scope{}, &Scope{}, &Scope{}}.GetDecidingScope()
+	assert.False(t, found)
+}
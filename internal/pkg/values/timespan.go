@@ -35,14 +35,21 @@ var (
 type TimeSpan interface {
 	// isTimeInSpan checks if time is in the timespan or not
 	isTimeInSpan(time time.Time, scopes Scopes) (bool, error)
+	// nextBoundary gets the next time after the given time at which the timespan could start or end, returns false if there is none
+	nextBoundary(after time.Time, scopes Scopes) (time.Time, bool)
 }
 
 type timeSpans []TimeSpan
 
 // inTimeSpans checks if current time is in one of the timespans or not.
 func (t *timeSpans) inTimeSpans(scopes Scopes) (bool, error) {
+	return t.inTimeSpansAt(time.Now(), scopes)
+}
+
+// inTimeSpansAt checks if the target time is in one of the timespans or not.
+func (t *timeSpans) inTimeSpansAt(targetTime time.Time, scopes Scopes) (bool, error) {
 	for _, timespan := range *t {
-		isTimeInSpan, err := timespan.isTimeInSpan(time.Now(), scopes)
+		isTimeInSpan, err := timespan.isTimeInSpan(targetTime, scopes)
 		if err != nil {
 			return false, fmt.Errorf("failed to check timespan: %w", err)
 		}
@@ -57,6 +64,27 @@ func (t *timeSpans) inTimeSpans(scopes Scopes) (bool, error) {
 	return false, nil
 }
 
+// nextBoundary gets the earliest next boundary of all timespans after the given time.
+func (t *timeSpans) nextBoundary(after time.Time, scopes Scopes) (time.Time, bool) {
+	var next time.Time
+
+	found := false
+
+	for _, timespan := range *t {
+		boundary, ok := timespan.nextBoundary(after, scopes)
+		if !ok {
+			continue
+		}
+
+		if !found || boundary.Before(next) {
+			next = boundary
+			found = true
+		}
+	}
+
+	return next, found
+}
+
 // String implementation for timeSpans.
 func (t *timeSpans) String() string {
 	if *t != nil {
@@ -265,6 +293,40 @@ func (t relativeTimeSpan) isTimeInSpan(targetTime time.Time, scopes Scopes) (boo
 	return defaultedTimeSpan.isTimeOfDayInRange(timeOfDay) && defaultedTimeSpan.isWeekdayInRange(weekday), nil
 }
 
+// nextBoundary gets the next start of the time of day range, end of the time of day range or start of a day after the given time.
+// Boundaries are only searched within the next week, since a relative timespan repeats weekly.
+func (t relativeTimeSpan) nextBoundary(after time.Time, scopes Scopes) (time.Time, bool) {
+	defaultedTimeSpan, err := t.defaultTimeSpan(scopes)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	localTime := after.In(defaultedTimeSpan.timezone)
+
+	for day := range 8 {
+		midnight := time.Date(localTime.Year(), localTime.Month(), localTime.Day()+day, 0, 0, 0, 0, defaultedTimeSpan.timezone)
+		candidates := []time.Time{
+			midnight,
+			midnight.Add(time.Duration(*defaultedTimeSpan.timeFrom) * time.Minute),
+			midnight.Add(time.Duration(*defaultedTimeSpan.timeTo) * time.Minute),
+		}
+
+		var next time.Time
+
+		for _, candidate := range candidates {
+			if candidate.After(after) && (next.IsZero() || candidate.Before(next)) {
+				next = candidate
+			}
+		}
+
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
 // String implementation for relativeTimeSpan.
 func (t relativeTimeSpan) String() string {
 	return fmt.Sprintf(
@@ -287,6 +349,19 @@ func (t absoluteTimeSpan) isTimeInSpan(targetTime time.Time, _ Scopes) (bool, er
 	return (t.from.Before(targetTime) || t.from.Equal(targetTime)) && t.to.After(targetTime), nil
 }
 
+// nextBoundary gets the start or end of the span if it is after the given time.
+func (t absoluteTimeSpan) nextBoundary(after time.Time, _ Scopes) (time.Time, bool) {
+	if t.from.After(after) {
+		return t.from, true
+	}
+
+	if t.to.After(after) {
+		return t.to, true
+	}
+
+	return time.Time{}, false
+}
+
 // String implementation for absoluteTimeSpan.
 func (t absoluteTimeSpan) String() string {
 	return fmt.Sprintf(
@@ -368,6 +443,15 @@ func (s directionalTimeSpan) isTimeInSpan(targetTime time.Time, _ Scopes) (bool,
 	return false, newIsTimeInSpanError("unknown timespan mode")
 }
 
+// nextBoundary gets the time of the span if it is after the given time.
+func (s directionalTimeSpan) nextBoundary(after time.Time, _ Scopes) (time.Time, bool) {
+	if s.time.After(after) {
+		return s.time, true
+	}
+
+	return time.Time{}, false
+}
+
 // String implementation for directionalTimeSpan.
 func (s directionalTimeSpan) String() string {
 	return fmt.Sprintf(
@@ -436,6 +520,10 @@ type booleanTimeSpan bool
 
 func (b booleanTimeSpan) isTimeInSpan(_ time.Time, _ Scopes) (bool, error) { return bool(b), nil }
 
+func (b booleanTimeSpan) nextBoundary(_ time.Time, _ Scopes) (time.Time, bool) {
+	return time.Time{}, false
+}
+
 // parseBooleanTimeSpan tries to parse the given timespan string to a booleanTimespan.
 func parseBooleanTimeSpan(timespanString string) (booleanTimeSpan, bool) {
 	switch strings.ToLower(timespanString) {
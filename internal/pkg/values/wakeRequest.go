@@ -0,0 +1,86 @@
+package values
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/caas-team/gokubedownscaler/internal/pkg/util"
+)
+
+// parseWakeRequest fills the wake request of the scope from the annotations.
+// A requested duration takes precedence over an absolute time, since it is a new request which wasn't converted yet.
+func (s *Scope) parseWakeRequest(annotations map[string]string, logEvent util.ResourceLogger, ctx context.Context) error {
+	if wakeFor, ok := annotations[annotationWakeFor]; ok {
+		var duration util.DurationValue
+
+		err := duration.Set(wakeFor)
+		if err == nil && duration <= 0 {
+			err = newInvalidValueError("the wake duration has to be positive", wakeFor)
+		}
+
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q annotation: %w", annotationWakeFor, err)
+			logEvent.ErrorInvalidAnnotation(annotationWakeFor, err.Error(), ctx)
+
+			return err
+		}
+
+		wakeUntil := time.Now().Add(time.Duration(duration)).Truncate(time.Second)
+		s.WakeUntil = &wakeUntil
+		s.WakeRequested = true
+
+		return nil
+	}
+
+	if wakeUntilString, ok := annotations[annotationWakeUntil]; ok {
+		wakeUntil, err := time.Parse(time.RFC3339, wakeUntilString)
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q annotation: %w", annotationWakeUntil, err)
+			logEvent.ErrorInvalidAnnotation(annotationWakeUntil, err.Error(), ctx)
+
+			return err
+		}
+
+		s.WakeUntil = &wakeUntil
+	}
+
+	return nil
+}
+
+// GetWakeUpdate gets how the wake request annotations have to be updated.
+// It returns the time to persist for a new wake request, nil for an expired one or false if no update is needed.
+func (s *Scope) GetWakeUpdate(now time.Time) (*time.Time, bool) {
+	if s.WakeUntil == nil {
+		return nil, false
+	}
+
+	if s.WakeRequested {
+		return s.WakeUntil, true
+	}
+
+	if now.Before(*s.WakeUntil) {
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// SetWakeAnnotations gets a copy of the annotations with the wake request replaced by the absolute time it expires at.
+// If the time is nil, the wake request is removed.
+func SetWakeAnnotations(annotations map[string]string, wakeUntil *time.Time) map[string]string {
+	result := maps.Clone(annotations)
+	if result == nil {
+		result = make(map[string]string)
+	}
+
+	delete(result, annotationWakeFor)
+	delete(result, annotationWakeUntil)
+
+	if wakeUntil != nil {
+		result[annotationWakeUntil] = wakeUntil.UTC().Format(time.RFC3339)
+	}
+
+	return result
+}
@@ -0,0 +1,197 @@
+package values
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discardLogger is a resource logger ignoring all events.
+type discardLogger struct{}
+
+func (discardLogger) ErrorInvalidAnnotation(string, string, context.Context) {}
+
+func (discardLogger) ErrorIncompatibleFields(string, context.Context) {}
+
+func TestScope_parseWakeRequest(t *testing.T) {
+	t.Parallel()
+
+	wakeUntil := time.Date(2025, time.June, 6, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		wantErr           bool
+		wantWakeFor       time.Duration
+		wantWakeUntil     *time.Time
+		wantWakeRequested bool
+	}{
+		{
+			name:        "no wake request",
+			annotations: map[string]string{},
+		},
+		{
+			name:              "new wake request",
+			annotations:       map[string]string{annotationWakeFor: "2h"},
+			wantWakeFor:       2 * time.Hour,
+			wantWakeRequested: true,
+		},
+		{
+			name:              "new wake request takes precedence",
+			annotations:       map[string]string{annotationWakeFor: "3600", annotationWakeUntil: wakeUntil.Format(time.RFC3339)},
+			wantWakeFor:       time.Hour,
+			wantWakeRequested: true,
+		},
+		{
+			name:          "converted wake request",
+			annotations:   map[string]string{annotationWakeUntil: wakeUntil.Format(time.RFC3339)},
+			wantWakeUntil: &wakeUntil,
+		},
+		{
+			name:        "negative duration",
+			annotations: map[string]string{annotationWakeFor: "-2h"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid time",
+			annotations: map[string]string{annotationWakeUntil: "tomorrow"},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			scope := NewScope()
+			before := time.Now()
+
+			err := scope.parseWakeRequest(test.annotations, discardLogger{}, context.Background())
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantWakeRequested, scope.WakeRequested)
+
+			switch {
+			case test.wantWakeFor != 0:
+				require.NotNil(t, scope.WakeUntil)
+				assert.WithinDuration(t, before.Add(test.wantWakeFor), *scope.WakeUntil, 2*time.Second)
+			case test.wantWakeUntil != nil:
+				require.NotNil(t, scope.WakeUntil)
+				assert.True(t, test.wantWakeUntil.Equal(*scope.WakeUntil))
+			default:
+				assert.Nil(t, scope.WakeUntil)
+			}
+		})
+	}
+}
+
+func TestScope_GetWakeUpdate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		scope         *Scope
+		wantWakeUntil *time.Time
+		wantUpdate    bool
+	}{
+		{
+			name:       "no wake request",
+			scope:      &Scope{},
+			wantUpdate: false,
+		},
+		{
+			name:          "new wake request is converted",
+			scope:         &Scope{WakeUntil: &later, WakeRequested: true},
+			wantWakeUntil: &later,
+			wantUpdate:    true,
+		},
+		{
+			name:       "active wake request is kept",
+			scope:      &Scope{WakeUntil: &later},
+			wantUpdate: false,
+		},
+		{
+			name:       "expired wake request is removed",
+			scope:      &Scope{WakeUntil: &earlier},
+			wantUpdate: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			wakeUntil, update := test.scope.GetWakeUpdate(now)
+			assert.Equal(t, test.wantUpdate, update)
+			assert.Equal(t, test.wantWakeUntil, wakeUntil)
+		})
+	}
+}
+
+func TestSetWakeAnnotations(t *testing.T) {
+	t.Parallel()
+
+	wakeUntil := time.Date(2025, time.June, 6, 20, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	annotations := map[string]string{annotationWakeFor: "2h", annotationDowntime: "always"}
+
+	converted := SetWakeAnnotations(annotations, &wakeUntil)
+	assert.Equal(t, map[string]string{annotationWakeUntil: "2025-06-06T18:00:00Z", annotationDowntime: "always"}, converted)
+	assert.Contains(t, annotations, annotationWakeFor, "the original annotations shouldn't be changed")
+
+	removed := SetWakeAnnotations(converted, nil)
+	assert.Equal(t, map[string]string{annotationDowntime: "always"}, removed)
+}
+
+func TestScopes_GetCurrentScaling_WakeRequest(t *testing.T) {
+	t.Parallel()
+
+	later := time.Now().Add(time.Hour)
+	earlier := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		scopes Scopes
+		want   Scaling
+	}{
+		{
+			name: "namespace wake request overrides downtime",
+			scopes: Scopes{
+				&Scope{}, &Scope{WakeUntil: &later}, &Scope{}, &Scope{DownTime: timeSpans{booleanTimeSpan(true)}}, &Scope{},
+			},
+			want: ScalingUp,
+		},
+		{
+			name: "wake request overrides force downtime of the same scope",
+			scopes: Scopes{
+				&Scope{WakeUntil: &later, ForceDowntime: timeSpans{booleanTimeSpan(true)}}, &Scope{}, &Scope{}, &Scope{}, &Scope{},
+			},
+			want: ScalingUp,
+		},
+		{
+			name: "expired wake request is ignored",
+			scopes: Scopes{
+				&Scope{WakeUntil: &earlier}, &Scope{}, &Scope{}, &Scope{DownTime: timeSpans{booleanTimeSpan(true)}}, &Scope{},
+			},
+			want: ScalingDown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, test.scopes.GetCurrentScaling())
+		})
+	}
+}